@@ -0,0 +1,147 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+)
+
+//----------------------------------------------------------------------------------------
+
+// BatchInput Inputs of one evaluation in a batch. Each batch function uses only the fields
+// it needs: CurrentBatch (Ta, Tc), TcBatch (Ta, Ic) and TaBatch (Tc, Ic).
+type BatchInput struct {
+	Ta         float64     // Ambient temperature [°C]
+	Tc         float64     // Conductor temperature [°C]
+	Ic         float64     // Current [A]
+	Conditions *Conditions // Weather record for this input (nil uses CurrentCalc conditions)
+}
+
+// BatchResult Result of one evaluation in a batch
+type BatchResult struct {
+	Value float64 // Calculated value (NaN if Err != nil)
+	Err   error   // Error of this evaluation
+}
+
+//----------------------------------------------------------------------------------------
+
+// CurrentBatch Returns Current(Ta, Tc) for every input. Results keep the order of inputs.
+// ctx     context.Context : Cancels the batch (inputs not evaluated get Err = ctx.Err())
+// inputs  []BatchInput    : Inputs to evaluate
+// workers int             : Number of goroutines (workers <= 0 uses runtime.NumCPU())
+func (cc *CurrentCalc) CurrentBatch(ctx context.Context, inputs []BatchInput,
+	workers int) ([]BatchResult, error) {
+	return cc.batch(ctx, inputs, workers, func(c *CurrentCalc, in *BatchInput) (float64, error) {
+		return c.Current(in.Ta, in.Tc)
+	})
+}
+
+// TcBatch Returns Tc(Ta, Ic) for every input. Results keep the order of inputs.
+// See CurrentBatch for arguments.
+func (cc *CurrentCalc) TcBatch(ctx context.Context, inputs []BatchInput,
+	workers int) ([]BatchResult, error) {
+	return cc.batch(ctx, inputs, workers, func(c *CurrentCalc, in *BatchInput) (float64, error) {
+		return c.Tc(in.Ta, in.Ic)
+	})
+}
+
+// TaBatch Returns Ta(Tc, Ic) for every input. Results keep the order of inputs.
+// See CurrentBatch for arguments.
+func (cc *CurrentCalc) TaBatch(ctx context.Context, inputs []BatchInput,
+	workers int) ([]BatchResult, error) {
+	return cc.batch(ctx, inputs, workers, func(c *CurrentCalc, in *BatchInput) (float64, error) {
+		return c.Ta(in.Tc, in.Ic)
+	})
+}
+
+// batch Evaluates f for every input using a copy of cc with the input conditions
+func (cc *CurrentCalc) batch(ctx context.Context, inputs []BatchInput, workers int,
+	f func(*CurrentCalc, *BatchInput) (float64, error)) ([]BatchResult, error) {
+	results := make([]BatchResult, len(inputs))
+	sent, err := runBatch(ctx, len(inputs), workers, func(i int) {
+		in := &inputs[i]
+		c := cc
+		if in.Conditions != nil {
+			x, err := cc.WithConditions(*in.Conditions)
+			if err != nil {
+				results[i] = BatchResult{math.NaN(), err}
+				return
+			}
+			c = x
+		}
+		v, err := f(c, in)
+		results[i] = BatchResult{v, err}
+	})
+	fillCancelled(results[sent:], err)
+	return results, err
+}
+
+//----------------------------------------------------------------------------------------
+
+// CurrentBatch Returns Current(ta) for every ambient temperature of tas. Results keep the
+// order of tas. See CurrentCalc.CurrentBatch for ctx and workers.
+func (ot *OperatingTable) CurrentBatch(ctx context.Context, tas []float64,
+	workers int) ([]BatchResult, error) {
+	results := make([]BatchResult, len(tas))
+	sent, err := runBatch(ctx, len(tas), workers, func(i int) {
+		v, err := ot.Current(tas[i])
+		results[i] = BatchResult{v, err}
+	})
+	fillCancelled(results[sent:], err)
+	return results, err
+}
+
+//----------------------------------------------------------------------------------------
+
+// runBatch Calls f(i) for i in [0, n) using a pool of workers goroutines. Stops sending
+// work when ctx is done and returns the number of calls made and ctx.Err().
+func runBatch(ctx context.Context, n int, workers int, f func(i int)) (int, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f(i)
+			}
+		}()
+	}
+
+	var err error
+	sent := 0
+loop:
+	for ; sent < n; sent++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break loop
+		case jobs <- sent:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return sent, err
+}
+
+// fillCancelled Sets results not evaluated because of cancellation error err
+func fillCancelled(results []BatchResult, err error) {
+	for i := range results {
+		results[i] = BatchResult{math.NaN(), err}
+	}
+}
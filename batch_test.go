@@ -0,0 +1,126 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"context"
+	"testing"
+)
+
+func getBatchInputs(n int) []BatchInput {
+	inputs := make([]BatchInput, n)
+	for i := range inputs {
+		ta := float64(i%60) - 10
+		inputs[i] = BatchInput{Ta: ta, Tc: ta + 40, Ic: 300 + float64(i%500)}
+	}
+	return inputs
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_CurrentCalc_CurrentBatch(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	inputs := getBatchInputs(200)
+	c := Conditions{1000, 3, 45, 0.5, 0.9}
+	inputs[7].Conditions = &c
+	inputs[9].Ta = TA_MAX + 1
+
+	results, err := cc.CurrentBatch(context.Background(), inputs, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		x := cc
+		if inputs[i].Conditions != nil {
+			x, _ = cc.WithConditions(*inputs[i].Conditions)
+		}
+		want, werr := x.Current(inputs[i].Ta, inputs[i].Tc)
+		if (werr == nil) != (r.Err == nil) {
+			t.Errorf("%d: error %v expected got %v", i, werr, r.Err)
+		}
+		if werr == nil && r.Value != want {
+			t.Errorf("%d: %f expected got %f", i, want, r.Value)
+		}
+	}
+	if results[9].Err == nil {
+		t.Error("ta > TA_MAX error expected")
+	}
+}
+
+func Test_CurrentCalc_TcTaBatch(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	inputs := getBatchInputs(50)
+
+	results, err := cc.TcBatch(context.Background(), inputs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		want, _ := cc.Tc(inputs[i].Ta, inputs[i].Ic)
+		if r.Err != nil || r.Value != want {
+			t.Errorf("%d: %f expected got %f (%v)", i, want, r.Value, r.Err)
+		}
+	}
+
+	results, err = cc.TaBatch(context.Background(), inputs, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		want, werr := cc.Ta(inputs[i].Tc, inputs[i].Ic)
+		if (werr == nil) != (r.Err == nil) || (werr == nil && r.Value != want) {
+			t.Errorf("%d: %f expected got %f (%v)", i, want, r.Value, r.Err)
+		}
+	}
+}
+
+func Test_CurrentCalc_BatchCancel(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := cc.TcBatch(ctx, getBatchInputs(100), 2)
+	if err != context.Canceled {
+		t.Errorf("context.Canceled expected got %v", err)
+	}
+	if len(results) != 100 {
+		t.Fatal("Len = 100 expected")
+	}
+	if results[99].Err != context.Canceled {
+		t.Error("Inputs not evaluated must have Err = ctx.Err()")
+	}
+
+	results, err = cc.CurrentBatch(context.Background(), nil, 2)
+	if err != nil || len(results) != 0 {
+		t.Error("Empty batch expected")
+	}
+}
+
+func Test_OperatingTable_CurrentBatch(t *testing.T) {
+	opi1, _ := NewOperatingItem(getCurrentCalc(), 50, 1)
+	opi2, _ := NewOperatingItem(getCurrentCalc(), 60, 1)
+	ot, _ := NewOperatingTable([]*OperatingItem{opi1, opi2}, "")
+	tas := []float64{0, 10, 20, 30, 40}
+
+	results, err := ot.CurrentBatch(context.Background(), tas, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		want, _ := ot.Current(tas[i])
+		if r.Value != want {
+			t.Errorf("%d: %f expected got %f", i, want, r.Value)
+		}
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Benchmark_CurrentCalc_TcBatch(b *testing.B) {
+	cc, _ := NewCurrentCalc(getConductor())
+	inputs := getBatchInputs(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cc.TcBatch(context.Background(), inputs, 0)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+//----------------------------------------------------------------------------------------
+
+// carson Returns the earth return correction terms P and Q of Carson's equations for
+// distance d [m] between a conductor and the image of other (2h for self terms), angle
+// theta [rad] between the image line and the vertical, frequency f [Hz] and earth
+// resistivity rho [Ohm*m]. Uses the series for k <= 5 and the asymptotic expansion
+// otherwise (Dommel, EMTP Theory Book).
+func carson(d float64, theta float64, f float64, rho float64) (float64, float64) {
+	k := 4 * math.Pi * math.Sqrt(5) * 1e-4 * d * math.Sqrt(f/rho)
+	if k > 5 {
+		c := func(i int) float64 { return math.Cos(float64(i)*theta) / math.Pow(k, float64(i)) }
+		p := c(1)/math.Sqrt2 - c(2) + c(3)/math.Sqrt2 + 3*c(5)/math.Sqrt2 - 45*c(7)/math.Sqrt2
+		q := c(1)/math.Sqrt2 - c(3)/math.Sqrt2 + 3*c(5)/math.Sqrt2 + 45*c(7)/math.Sqrt2
+		return p, q
+	}
+	p := math.Pi / 8
+	q := -0.0386 + 0.5*math.Log(2/k)
+	lnk := math.Log(k)
+	b := [2]float64{1.0 / 16, math.Sqrt2 / 6} // b(i) for even and odd i
+	c := 1.3659315                            // c(i) for even i
+	sign := 1.0
+	for i := 1; i <= 40; i++ {
+		fi := float64(i)
+		if i > 2 {
+			if (i-1)%4 == 0 {
+				sign = -sign
+			}
+			b[i%2] *= sign / (fi * (fi + 2))
+			if i%2 == 0 {
+				c += 1/fi + 1/(fi+2)
+			}
+		}
+		bi := b[i%2]
+		ki := math.Pow(k, fi)
+		cos := ki * math.Cos(fi*theta)
+		di := math.Pi / 4 * bi * cos
+		switch i % 4 {
+		case 1:
+			p -= bi * cos
+			q += bi * cos
+		case 2:
+			p += bi * ((c-lnk)*cos + theta*ki*math.Sin(fi*theta))
+			q -= di
+		case 3:
+			p += bi * cos
+			q += bi * cos
+		case 0:
+			p -= di
+			q -= bi * ((c-lnk)*cos + theta*ki*math.Sin(fi*theta))
+		}
+	}
+	return p, q
+}
+
+//----------------------------------------------------------------------------------------
+
+// PhaseImpedance Returns the series impedance matrix of the phases [Ohm/km] with
+// Carson's equations and shield wires eliminated with Kron reduction (shields grounded at
+// every tower). Row and column i are phase i of NewLineGeometry.
+// t   float64 : Conductor temperature [°C]
+// rho float64 : Earth resistivity [Ohm*m] (required rho > 0)
+func (lg *LineGeometry) PhaseImpedance(t float64, rho float64) ([][]complex128, error) {
+	return lg.phaseImpedance("LineGeometry.PhaseImpedance", t, rho)
+}
+
+func (lg *LineGeometry) phaseImpedance(op string, t float64, rho float64) (cmatrix, error) {
+	if t < TC_MIN {
+		return nil, &RangeError{op, "t", "<", "TC_MIN", TC_MIN, t}
+	}
+	if t > TC_MAX {
+		return nil, &RangeError{op, "t", ">", "TC_MAX", TC_MAX, t}
+	}
+	if rho <= 0 {
+		return nil, &RangeError{op, "rho", "<=", "0", 0, rho}
+	}
+	omega := 2 * math.Pi * lg.frequency
+	z := newCMatrix(len(lg.wires))
+	for i := range lg.wires {
+		a := &lg.wires[i]
+		for j := range lg.wires {
+			b := &lg.wires[j]
+			d := math.Hypot(a.X-b.X, a.Y+b.Y) // Distance to image
+			theta := math.Acos((a.Y + b.Y) / d)
+			p, q := carson(d, theta, lg.frequency, rho)
+			x := omega * MU0_2PI * (lg.logRatio(i, j, a.BundleGMR()) + 2*q)
+			z[i][j] = complex(omega*MU0_2PI*2*p, x) // 4ωP·1e-4 and 4ωQ·1e-4 [Ohm/km]
+		}
+		z[i][i] += complex(a.resistance(t), 0)
+	}
+	zr, ok := z.kron(lg.nphases)
+	if !ok {
+		return nil, &ConfigError{op, "shields", "singular matrix"}
+	}
+	return zr, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// CircuitParams Sequence parameters of a circuit
+type CircuitParams struct {
+	Positive LineParams       // Positive sequence of the transposed circuit
+	Zero     LineParams       // Zero sequence of the transposed circuit
+	Z012     [3][3]complex128 // Sequence impedance matrix of the untransposed circuit [Ohm/km]
+}
+
+// SequenceParams Sequence parameters of a LineGeometry
+type SequenceParams struct {
+	Circuits []CircuitParams // Parameters of each circuit
+	Z0m      [][]complex128  // Zero sequence mutual impedance between circuits [Ohm/km]
+}
+
+// Sequence Returns positive and zero sequence parameters of every circuit and the zero
+// sequence mutual impedance between circuits. Series impedances use Carson's equations
+// (see PhaseImpedance); susceptances use images in a perfect conducting earth.
+// t   float64 : Conductor temperature [°C]
+// rho float64 : Earth resistivity [Ohm*m] (required rho > 0)
+func (lg *LineGeometry) Sequence(t float64, rho float64) (*SequenceParams, error) {
+	op := "LineGeometry.Sequence"
+	z, err := lg.phaseImpedance(op, t, rho)
+	if err != nil {
+		return nil, err
+	}
+	c, err := lg.capacitance(op)
+	if err != nil {
+		return nil, err
+	}
+	omega := 2 * math.Pi * lg.frequency
+	n := lg.Circuits()
+	sp := &SequenceParams{make([]CircuitParams, n), make([][]complex128, n)}
+	for a := 0; a < n; a++ {
+		sp.Z0m[a] = make([]complex128, n)
+		for b := 0; b < n; b++ {
+			s, m := selfMutual(z, a, b)
+			if a == b {
+				cs, cm := selfMutual(c, a, a)
+				z1, z0 := s-m, s+2*m
+				cp := &sp.Circuits[a]
+				cp.Positive = LineParams{real(z1), imag(z1), omega * real(cs-cm)}
+				cp.Zero = LineParams{real(z0), imag(z0), omega * real(cs+2*cm)}
+				cp.Z012 = symmetrical(z, a)
+			} else {
+				sp.Z0m[a][b] = 3 * s
+			}
+		}
+	}
+	return sp, nil
+}
+
+// symmetrical Returns A⁻¹·Z·A for the 3 x 3 block of circuit k of z, with A the matrix
+// of symmetrical components
+func symmetrical(z cmatrix, k int) [3][3]complex128 {
+	a := cmplx.Rect(1, 2*math.Pi/3)
+	A := [3][3]complex128{{1, 1, 1}, {1, a * a, a}, {1, a, a * a}}
+	Ainv := [3][3]complex128{{1, 1, 1}, {1, a, a * a}, {1, a * a, a}}
+	var za, r [3][3]complex128
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for p := 0; p < 3; p++ {
+				za[i][j] += z[3*k+i][3*k+p] * A[p][j]
+			}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for p := 0; p < 3; p++ {
+				r[i][j] += Ainv[i][p] * za[p][j] / 3
+			}
+		}
+	}
+	return r
+}
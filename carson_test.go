@@ -0,0 +1,113 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// getDoubleCircuit Returns a 220 kV double circuit tower with DRAKE conductors and two
+// shield wires
+func getDoubleCircuit() *LineGeometry {
+	s := getStranding()
+	c := s.Conductor(CC_ACSR)
+	gmr := s.GMR()
+	shield := NewConductor("OPGW", CC_ACSR, 14, 100, 0.5, 8000, 0.4, 0, "")
+	lg, _ := NewLineGeometry([]LineWire{
+		{c, -5, 30, 1, 0, gmr}, {c, -5.5, 24, 1, 0, gmr}, {c, -6, 18, 1, 0, gmr},
+		{c, 5, 30, 1, 0, gmr}, {c, 5.5, 24, 1, 0, gmr}, {c, 6, 18, 1, 0, gmr},
+	}, []LineWire{{shield, -4, 36, 1, 0, 0}, {shield, 4, 36, 1, 0, 0}}, 50)
+	return lg
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Carson_Terms(t *testing.T) {
+	// Low frequency limit ΔR = ωμ0/8
+	if p, _ := carson(1e-3, 0, 50, 100); math.Abs(p-math.Pi/8) > 1e-3 {
+		t.Errorf("%f expected got %f", math.Pi/8, p)
+	}
+	// Complex depth approximation j·ln(D''/D') = 2(P + jQ) within 3%
+	for _, x := range []struct{ d, dx, rho float64 }{{40, 0, 100}, {40, 10, 100},
+		{60, 20, 10}, {5000, 0, 10}, {30, 0, 1000}} {
+		theta := math.Asin(x.dx / x.d)
+		h := math.Sqrt(x.d*x.d - x.dx*x.dx)
+		p, q := carson(x.d, theta, 50, x.rho)
+		depth := cmplx.Sqrt(complex(x.rho/(2*math.Pi*50*4*math.Pi*1e-7), 0) / 1i)
+		h2 := complex(h, 0) + 2*depth
+		want := 1i * cmplx.Log(cmplx.Sqrt(h2*h2+complex(x.dx*x.dx, 0))/complex(x.d, 0)) / 2
+		got := complex(p, q)
+		if cmplx.Abs(got-want) > 0.03*cmplx.Abs(want) {
+			t.Errorf("%+v: %v expected got %v", x, want, got)
+		}
+	}
+}
+
+func Test_Carson_Sequence(t *testing.T) {
+	lg := getDoubleCircuit()
+	sp, err := lg.Sequence(50, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sp.Circuits) != 2 {
+		t.Fatalf("2 circuits expected got %d", len(sp.Circuits))
+	}
+	lp, _ := lg.PositiveSequence(50)
+	for i, cp := range sp.Circuits {
+		// Earth return does not change positive sequence
+		if math.Abs(cp.Positive.X-lp.X) > 0.01*lp.X || math.Abs(cp.Positive.B-lp.B) > 0.01*lp.B {
+			t.Errorf("%d: positive sequence %+v expected got %+v", i, lp, cp.Positive)
+		}
+		if cp.Zero.X < 2*cp.Positive.X || cp.Zero.R < cp.Positive.R+0.1 ||
+			cp.Zero.B >= cp.Positive.B {
+			t.Errorf("%d: zero sequence expected got %+v", i, cp.Zero)
+		}
+		// Diagonal of Z012 of the untransposed circuit
+		if cmplx.Abs(cp.Z012[0][0]-cp.Zero.Z()) > 1e-9 || cmplx.Abs(cp.Z012[1][1]-
+			cp.Positive.Z()) > 1e-9 {
+			t.Errorf("%d: Z012 diagonal expected got %v", i, cp.Z012)
+		}
+	}
+	z0m := sp.Z0m[0][1]
+	if cmplx.Abs(z0m-sp.Z0m[1][0]) > 1e-12 || imag(z0m) <= 0 || imag(z0m) >= sp.Circuits[0].Zero.X {
+		t.Errorf("Zero sequence mutual expected got %v", z0m)
+	}
+
+	// Higher earth resistivity raises zero sequence reactance
+	sp2, _ := lg.Sequence(50, 1000)
+	if sp2.Circuits[0].Zero.X <= sp.Circuits[0].Zero.X {
+		t.Errorf("X0 > %f expected got %f", sp.Circuits[0].Zero.X, sp2.Circuits[0].Zero.X)
+	}
+	if _, err := lg.Sequence(50, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+func Test_Carson_PhaseImpedance(t *testing.T) {
+	z, err := getDoubleCircuit().PhaseImpedance(50, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(z) != 6 || len(z[0]) != 6 {
+		t.Fatalf("6 x 6 matrix expected got %d", len(z))
+	}
+	for i := range z {
+		for j := range z {
+			if cmplx.Abs(z[i][j]-z[j][i]) > 1e-12 {
+				t.Errorf("Symmetric matrix expected z[%d][%d] %v z[%d][%d] %v", i, j, z[i][j],
+					j, i, z[j][i])
+			}
+		}
+	}
+}
+
+func Example_LineGeometry_Sequence() {
+	sp, _ := getDoubleCircuit().Sequence(50, 100)
+	cp := sp.Circuits[0]
+	fmt.Printf("Z1=%.4f Z0=%.4f Z0m=%.4f Ohm/km\n", cp.Positive.Z(), cp.Zero.Z(), sp.Z0m[0][1])
+	// Output: Z1=(0.0803+0.4075i) Z0=(0.2693+0.9409i) Z0m=(0.1884+0.4363i) Ohm/km
+}
@@ -0,0 +1,169 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// NewCatenary Returns *Catenary object
+// span float64 : Horizontal distance between supports [m] (required span > 0)
+// dh   float64 : Height of the right support above the left support [m] (< 0 if lower)
+// h    float64 : Horizontal tension [kg] (0 < h <= TENSION_MAX)
+// w    float64 : Unit weight, Conductor.Weight or UnitLoad.Resultant [kg/m] (required w > 0)
+func NewCatenary(span float64, dh float64, h float64, w float64) (*Catenary, error) {
+	return newCatenary("NewCatenary", span, dh, h, w)
+}
+
+func newCatenary(op string, span float64, dh float64, h float64, w float64) (*Catenary, error) {
+	if span <= 0 {
+		return nil, &RangeError{op, "span", "<=", "0", 0, span}
+	}
+	if h <= 0 {
+		return nil, &RangeError{op, "h", "<=", "0", 0, h}
+	}
+	if h > TENSION_MAX {
+		return nil, &RangeError{op, "h", ">", "TENSION_MAX", TENSION_MAX, h}
+	}
+	if w <= 0 {
+		return nil, &RangeError{op, "w", "<=", "0", 0, w}
+	}
+	c := h / w
+	xm := span/2 - c*math.Asinh(dh/(2*c*math.Sinh(span/(2*c))))
+	return &Catenary{span, dh, h, w, c, xm}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// Catenary Geometry of a conductor hanging between two supports. Distances x are measured
+// horizontally from the left support and heights from the left support upwards. Sags are
+// measured vertically below the chord between supports.
+type Catenary struct {
+	span float64 // Horizontal distance between supports [m]
+	dh   float64 // Height of the right support above the left support [m]
+	h    float64 // Horizontal tension [kg]
+	w    float64 // Unit weight [kg/m]
+	c    float64 // Catenary parameter h/w [m]
+	xm   float64 // Horizontal position of the low point [m]
+}
+
+// checkX Returns error if x is outside the span
+func (ct *Catenary) checkX(op string, x float64) error {
+	if x < 0 {
+		return &RangeError{op, "x", "<", "0", 0, x}
+	}
+	if x > ct.span {
+		return &RangeError{op, "x", ">", "span", ct.span, x}
+	}
+	return nil
+}
+
+// Height Returns the height of the conductor at x [m] above the left support
+func (ct *Catenary) Height(x float64) (float64, error) {
+	if err := ct.checkX("Catenary.Height", x); err != nil {
+		return math.NaN(), err
+	}
+	return ct.height(x), nil
+}
+
+func (ct *Catenary) height(x float64) float64 {
+	return ct.c * (math.Cosh((x-ct.xm)/ct.c) - math.Cosh(ct.xm/ct.c))
+}
+
+// Sag Returns the catenary sag at x [m]
+func (ct *Catenary) Sag(x float64) (float64, error) {
+	if err := ct.checkX("Catenary.Sag", x); err != nil {
+		return math.NaN(), err
+	}
+	return ct.dh*x/ct.span - ct.height(x), nil
+}
+
+// ParabolicSag Returns the parabolic sag w·x·(span - x)/(2h) at x [m]
+func (ct *Catenary) ParabolicSag(x float64) (float64, error) {
+	if err := ct.checkX("Catenary.ParabolicSag", x); err != nil {
+		return math.NaN(), err
+	}
+	return ct.w * x * (ct.span - x) / (2 * ct.h), nil
+}
+
+// MaxSag Returns the position [m] and value [m] of the maximum catenary sag, where the
+// conductor is parallel to the chord
+func (ct *Catenary) MaxSag() (float64, float64) {
+	x := ct.xm + ct.c*math.Asinh(ct.dh/ct.span)
+	return x, ct.dh*x/ct.span - ct.height(x)
+}
+
+// MidSag Returns the catenary sag at mid span [m]
+func (ct *Catenary) MidSag() float64 {
+	x := ct.span / 2
+	return ct.dh*x/ct.span - ct.height(x)
+}
+
+// LowPoint Returns the position [m] and height [m] of the lowest point of the catenary.
+// The position is outside [0, span] with uplift.
+func (ct *Catenary) LowPoint() (float64, float64) {
+	return ct.xm, ct.c * (1 - math.Cosh(ct.xm/ct.c))
+}
+
+// Uplift Returns true if the low point is outside the span, so the conductor pulls the
+// lower support upwards
+func (ct *Catenary) Uplift() bool {
+	return ct.xm < 0 || ct.xm > ct.span
+}
+
+// Length Returns the arc length of the conductor between supports [m]
+func (ct *Catenary) Length() float64 {
+	return ct.c * (math.Sinh((ct.span-ct.xm)/ct.c) + math.Sinh(ct.xm/ct.c))
+}
+
+// ParabolicLength Returns the arc length of the parabolic approximation
+// span + dh²/(2span) + w²span³/(24h²) [m]
+func (ct *Catenary) ParabolicLength() float64 {
+	return ct.span + ct.dh*ct.dh/(2*ct.span) + ct.w*ct.w*math.Pow(ct.span, 3)/(24*ct.h*ct.h)
+}
+
+// Tensions Returns the conductor tension at the left and right supports [kg]
+func (ct *Catenary) Tensions() (float64, float64) {
+	return ct.h * math.Cosh(ct.xm/ct.c), ct.h * math.Cosh((ct.span-ct.xm)/ct.c)
+}
+
+// VerticalLoads Returns the vertical loads of the conductor on the left and right supports
+// [kg], negative with uplift
+func (ct *Catenary) VerticalLoads() (float64, float64) {
+	return ct.h * math.Sinh(ct.xm/ct.c), ct.h * math.Sinh((ct.span-ct.xm)/ct.c)
+}
+
+func (ct *Catenary) Span() float64 {
+	return ct.span
+}
+
+func (ct *Catenary) Dh() float64 {
+	return ct.dh
+}
+
+func (ct *Catenary) H() float64 {
+	return ct.h
+}
+
+func (ct *Catenary) W() float64 {
+	return ct.w
+}
+
+// Param Returns the catenary parameter h/w [m]
+func (ct *Catenary) Param() float64 {
+	return ct.c
+}
+
+//----------------------------------------------------------------------------------------
+
+// Catenary Returns the catenary of the level span with the conductor weight at conductor
+// temperature t [°C]
+func (st *SagTensionCalc) Catenary(t float64) (*Catenary, error) {
+	h, err := st.Tension(t)
+	if err != nil {
+		return nil, &OpError{"SagTensionCalc.Catenary", err}
+	}
+	return newCatenary("SagTensionCalc.Catenary", st.span, 0, h, st.conductor.weight)
+}
@@ -0,0 +1,152 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func Test_Catenary_Level(t *testing.T) {
+	ct, err := NewCatenary(400, 0, 3000, 1.628)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := 3000 / 1.628
+	sag := c * (math.Cosh(200/c) - 1)
+	if math.Abs(ct.MidSag()-sag) > 1e-9 {
+		t.Errorf("%f expected got %f", sag, ct.MidSag())
+	}
+	if x, s := ct.MaxSag(); math.Abs(x-200) > 1e-9 || math.Abs(s-sag) > 1e-9 {
+		t.Errorf("%f at 200 expected got %f at %f", sag, s, x)
+	}
+	if x, y := ct.LowPoint(); math.Abs(x-200) > 1e-9 || math.Abs(y+sag) > 1e-9 {
+		t.Errorf("Low point at (200, %f) expected got (%f, %f)", -sag, x, y)
+	}
+	if l := 2 * c * math.Sinh(200/c); math.Abs(ct.Length()-l) > 1e-9 {
+		t.Errorf("%f expected got %f", l, ct.Length())
+	}
+	if ct.Uplift() {
+		t.Error("No uplift expected")
+	}
+
+	// Parabola is a close approximation for sag < 5% of span
+	ps, _ := ct.ParabolicSag(200)
+	if ps >= sag || (sag-ps)/sag > 0.001 {
+		t.Errorf("Parabolic sag slightly lower than %f expected got %f", sag, ps)
+	}
+	if d := ct.ParabolicLength() - ct.Length(); math.Abs(d) > 1e-3 {
+		t.Errorf("Parabolic length close to catenary expected got difference %f", d)
+	}
+	tl, tr := ct.Tensions()
+	if tl != tr || math.Abs(tl-(3000+1.628*sag)) > 1e-6 {
+		t.Errorf("Tension h + w*sag expected got %f %f", tl, tr)
+	}
+}
+
+func Test_Catenary_Inclined(t *testing.T) {
+	ct, err := NewCatenary(400, 40, 3000, 1.628)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y, _ := ct.Height(0); math.Abs(y) > 1e-9 {
+		t.Errorf("0 expected got %f", y)
+	}
+	if y, _ := ct.Height(400); math.Abs(y-40) > 1e-9 {
+		t.Errorf("40 expected got %f", y)
+	}
+	x, y := ct.LowPoint()
+	if x <= 0 || x >= 200 || y >= 0 {
+		t.Errorf("Low point in the left half below the left support expected got %f %f", x, y)
+	}
+	xs, smax := ct.MaxSag()
+	for _, xi := range []float64{0, 100, xs - 1, xs + 1, 300, 400} {
+		if s, _ := ct.Sag(xi); s > smax {
+			t.Errorf("Sag %f at %f greater than max sag %f", s, xi, smax)
+		}
+	}
+	if xs <= 200 {
+		t.Errorf("Max sag right of mid span expected got %f", xs)
+	}
+	vl, vr := ct.VerticalLoads()
+	if math.Abs(vl+vr-1.628*ct.Length()) > 1e-6 || vr <= vl {
+		t.Errorf("Weight of conductor expected got %f + %f", vl, vr)
+	}
+	tl, tr := ct.Tensions()
+	if math.Abs(tr-tl-1.628*40) > 1e-6 {
+		t.Errorf("Tension difference w*dh expected got %f", tr-tl)
+	}
+}
+
+func Test_Catenary_Uplift(t *testing.T) {
+	ct, _ := NewCatenary(300, 100, 3000, 1.628)
+	if !ct.Uplift() {
+		t.Error("Uplift expected")
+	}
+	if x, _ := ct.LowPoint(); x >= 0 {
+		t.Errorf("Low point left of the span expected got %f", x)
+	}
+	if vl, _ := ct.VerticalLoads(); vl >= 0 {
+		t.Errorf("Upward load on the left support expected got %f", vl)
+	}
+	ct, _ = NewCatenary(300, -100, 3000, 1.628)
+	if x, _ := ct.LowPoint(); !ct.Uplift() || x <= 300 {
+		t.Errorf("Low point right of the span expected got %f", x)
+	}
+}
+
+func Test_Catenary_SagTensionCalc(t *testing.T) {
+	st, _ := NewSagTensionCalc(getDrake(), 350, 15, 2850)
+	ct, err := st.Catenary(15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(ct.H()-2850) > 1e-3 || ct.W() != 1.628 || ct.Span() != 350 || ct.Dh() != 0 {
+		t.Errorf("Reference state expected got %+v", ct)
+	}
+	if ps, _ := ct.ParabolicSag(175); math.Abs(ps-st.SagAt(ct.H())) > 1e-9 {
+		t.Errorf("%f expected got %f", st.SagAt(ct.H()), ps)
+	}
+	if _, err := st.Catenary(TC_MAX + 1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+func Test_Catenary_Errors(t *testing.T) {
+	for _, a := range [][4]float64{{0, 0, 3000, 1}, {300, 0, 0, 1}, {300, 0, TENSION_MAX + 1, 1},
+		{300, 0, 3000, 0}} {
+		if _, err := NewCatenary(a[0], a[1], a[2], a[3]); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%v: range error expected got %v", a, err)
+		}
+	}
+	ct, _ := NewCatenary(300, 0, 3000, 1.628)
+	for _, x := range []float64{-1, 301} {
+		if _, err := ct.Sag(x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%f: range error expected got %v", x, err)
+		}
+		if _, err := ct.Height(x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%f: range error expected got %v", x, err)
+		}
+		if _, err := ct.ParabolicSag(x); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%f: range error expected got %v", x, err)
+		}
+	}
+}
+
+func Example_Catenary() {
+	ct, _ := NewCatenary(400, 40, 3000, 1.628)
+	x, s := ct.MaxSag()
+	xm, ym := ct.LowPoint()
+	tl, tr := ct.Tensions()
+	fmt.Printf("Max sag %.3f m at %.1f m\n", s, x)
+	fmt.Printf("Low point %.3f m at %.1f m\n", ym, xm)
+	fmt.Printf("Length %.3f m\n", ct.Length())
+	fmt.Printf("Tensions %.1f kg %.1f kg\n", tl, tr)
+	// Output:
+	// Max sag 10.918 m at 200.4 m
+	// Low point -0.073 m at 16.4 m
+	// Length 402.777 m
+	// Tensions 3000.1 kg 3065.2 kg
+}
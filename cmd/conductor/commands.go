@@ -0,0 +1,315 @@
+// Copyright Cristian Echeverría Rabí
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+
+	"github.com/cer1969/go-conductor"
+	"github.com/cer1969/go-conductor/server"
+)
+
+//----------------------------------------------------------------------------------------
+
+// newFlagSet Returns a flag set for command name writing messages to stderr
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("conductor "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parse Parses args. Messages of invalid flags are written by fs.
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return &usageError{help: true}
+		}
+		return &usageError{}
+	}
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
+
+// visited Returns the names of flags set in the command line
+func visited(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// conductorName Returns id of c or its name if id is empty
+func conductorName(c *conductor.Conductor) string {
+	if c.Id() != "" {
+		return c.Id()
+	}
+	return c.Name()
+}
+
+//----------------------------------------------------------------------------------------
+
+// batchFunc Evaluates a batch of inputs with a *CurrentCalc
+type batchFunc func(cc *conductor.CurrentCalc, ctx context.Context,
+	inputs []conductor.BatchInput, workers int) ([]conductor.BatchResult, error)
+
+func runCurrent(args []string, stdout io.Writer, stderr io.Writer) error {
+	return runCalc("current", args, stdout, stderr, []string{"ta", "tc"}, "current",
+		(*conductor.CurrentCalc).CurrentBatch)
+}
+
+func runTc(args []string, stdout io.Writer, stderr io.Writer) error {
+	return runCalc("tc", args, stdout, stderr, []string{"ta", "ic"}, "tc",
+		(*conductor.CurrentCalc).TcBatch)
+}
+
+func runTa(args []string, stdout io.Writer, stderr io.Writer) error {
+	return runCalc("ta", args, stdout, stderr, []string{"tc", "ic"}, "ta",
+		(*conductor.CurrentCalc).TaBatch)
+}
+
+// runCalc Runs command name that evaluates f for inputs (flags or weather columns) and
+// writes a column result
+func runCalc(name string, args []string, stdout io.Writer, stderr io.Writer, inputs []string,
+	result string, f batchFunc) error {
+	var cf conductorFlags
+	var wf weatherFlags
+	var of outputFlags
+	var in conductor.BatchInput
+	fs := newFlagSet(name, stderr)
+	cf.register(fs)
+	wf.register(fs, true)
+	of.register(fs)
+	fs.Float64Var(&in.Ta, "ta", 0, "ambient temperature [°C]")
+	fs.Float64Var(&in.Tc, "tc", 0, "conductor temperature [°C]")
+	fs.Float64Var(&in.Ic, "ic", 0, "current [A]")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := of.check(); err != nil {
+		return err
+	}
+	if err := wf.check(visited(fs)); err != nil {
+		return err
+	}
+
+	var list []conductor.BatchInput
+	var columns map[string]bool
+	if wf.file != "" {
+		var err error
+		if list, columns, err = wf.readWeather(in); err != nil {
+			return err
+		}
+	} else {
+		cond := wf.cond
+		in.Conditions = &cond
+		list = []conductor.BatchInput{in}
+	}
+	set := visited(fs)
+	for _, x := range inputs {
+		if !set[x] && !columns[x] {
+			return usagef("-%s is required", x)
+		}
+	}
+
+	c, err := cf.get()
+	if err != nil {
+		return err
+	}
+	cc, err := wf.currentCalc(c)
+	if err != nil {
+		return err
+	}
+	results, err := f(cc, context.Background(), list, 0)
+	if err != nil {
+		return err
+	}
+
+	t := table{conductor: conductorName(c),
+		columns: append(inputs[:len(inputs):len(inputs)], result)}
+	if wf.file != "" {
+		t.columns = append(t.columns, "altitude", "wind", "wind-angle", "sun", "emissivity")
+	}
+	values := map[string]func(*conductor.BatchInput) float64{
+		"ta": func(x *conductor.BatchInput) float64 { return x.Ta },
+		"tc": func(x *conductor.BatchInput) float64 { return x.Tc },
+		"ic": func(x *conductor.BatchInput) float64 { return x.Ic },
+	}
+	for i, r := range results {
+		x := &list[i]
+		row := []float64{values[inputs[0]](x), values[inputs[1]](x), r.Value}
+		if wf.file != "" {
+			row = append(row, x.Conditions.Altitude, x.Conditions.AirVelocity,
+				x.Conditions.WindAngle, x.Conditions.SunEffect, x.Conditions.Emissivity)
+		}
+		t.add(row, r.Err)
+	}
+	if wf.file == "" && t.errs != nil {
+		return t.errs[0]
+	}
+	return t.write(stdout, of.format)
+}
+
+//----------------------------------------------------------------------------------------
+
+func runRatingTable(args []string, stdout io.Writer, stderr io.Writer) error {
+	var cf conductorFlags
+	var wf weatherFlags
+	var of outputFlags
+	fs := newFlagSet("rating-table", stderr)
+	cf.register(fs)
+	wf.register(fs, false)
+	of.register(fs)
+	taMin := fs.Float64("ta-min", 0, "first ambient temperature [°C]")
+	taMax := fs.Float64("ta-max", 40, "last ambient temperature [°C]")
+	taStep := fs.Float64("ta-step", 5, "ambient temperature step [°C]")
+	tcList := fs.String("tc", "50,75", "comma separated conductor temperatures [°C]")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := of.check(); err != nil {
+		return err
+	}
+	if err := wf.check(visited(fs)); err != nil {
+		return err
+	}
+	if *taStep <= 0 || *taMax < *taMin {
+		return usagef("-ta-step must be > 0 and -ta-max >= -ta-min")
+	}
+	tcs, err := parseList("tc", *tcList)
+	if err != nil {
+		return err
+	}
+
+	c, err := cf.get()
+	if err != nil {
+		return err
+	}
+	cc, err := wf.currentCalc(c)
+	if err != nil {
+		return err
+	}
+	var tas []float64
+	for i := 0; ; i++ {
+		ta := *taMin + float64(i)*(*taStep)
+		if ta > *taMax+1e-9 {
+			break
+		}
+		tas = append(tas, ta)
+	}
+	inputs := make([]conductor.BatchInput, 0, len(tas)*len(tcs))
+	for _, ta := range tas {
+		for _, tc := range tcs {
+			inputs = append(inputs, conductor.BatchInput{Ta: ta, Tc: tc})
+		}
+	}
+	results, err := cc.CurrentBatch(context.Background(), inputs, 0)
+	if err != nil {
+		return err
+	}
+
+	t := table{conductor: conductorName(c), columns: []string{"ta"}}
+	for _, tc := range tcs {
+		t.columns = append(t.columns, fmt.Sprintf("current_%g", tc))
+	}
+	for i, ta := range tas {
+		row := []float64{ta}
+		var rowErr error
+		for _, r := range results[i*len(tcs) : (i+1)*len(tcs)] {
+			row = append(row, r.Value)
+			if rowErr == nil {
+				rowErr = r.Err
+			}
+		}
+		t.add(row, rowErr)
+	}
+	return t.write(stdout, of.format)
+}
+
+//----------------------------------------------------------------------------------------
+
+func runSag(args []string, stdout io.Writer, stderr io.Writer) error {
+	var cf conductorFlags
+	var of outputFlags
+	fs := newFlagSet("sag", stderr)
+	cf.register(fs)
+	of.register(fs)
+	span := fs.Float64("span", 0, "span length [m]")
+	t0 := fs.Float64("t0", 15, "conductor temperature of the reference state [°C]")
+	h0 := fs.Float64("h0", 0, "horizontal tension of the reference state [kg]")
+	h0Pct := fs.Float64("h0-pct", 0, "horizontal tension of the reference state [% of "+
+		"rated strength]")
+	tList := fs.String("t", "-10,0,15,25,50,75,100", "comma separated conductor "+
+		"temperatures [°C]")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := of.check(); err != nil {
+		return err
+	}
+	set := visited(fs)
+	if !set["span"] {
+		return usagef("-span is required")
+	}
+	if set["h0"] == set["h0-pct"] {
+		return usagef("one of -h0 or -h0-pct is required")
+	}
+	temps, err := parseList("t", *tList)
+	if err != nil {
+		return err
+	}
+
+	c, err := cf.get()
+	if err != nil {
+		return err
+	}
+	h := *h0
+	if set["h0-pct"] {
+		h = *h0Pct / 100 * c.Strength()
+	}
+	st, err := conductor.NewSagTensionCalc(c, *span, *t0, h)
+	if err != nil {
+		return err
+	}
+
+	t := table{conductor: conductorName(c), columns: []string{"t", "tension", "sag"}}
+	for _, temp := range temps {
+		tension, err := st.Tension(temp)
+		sag := math.NaN()
+		if err == nil {
+			sag = st.SagAt(tension)
+		}
+		t.add([]float64{temp, tension, sag}, err)
+	}
+	return t.write(stdout, of.format)
+}
+
+//----------------------------------------------------------------------------------------
+
+func runServe(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("serve", stderr)
+	addr := fs.String("addr", ":8080", "listen `address`")
+	catalog := fs.String("catalog", os.Getenv("CONDUCTOR_CATALOG"),
+		"catalog repository `dir` (default $CONDUCTOR_CATALOG)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	var repo conductor.Repository
+	if *catalog != "" {
+		r, err := conductor.OpenFileRepository(*catalog)
+		if err != nil {
+			return err
+		}
+		repo = r
+	}
+	fmt.Fprintf(stderr, "conductor serve: listening on %s\n", *addr)
+	return http.ListenAndServe(*addr, server.New(repo))
+}
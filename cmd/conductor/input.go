@@ -0,0 +1,291 @@
+// Copyright Cristian Echeverría Rabí
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cer1969/go-conductor"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Categories resolved by id in conductor files when there is no catalog
+var builtinCategories = []*conductor.Category{conductor.CC_CU, conductor.CC_AAAC,
+	conductor.CC_ACAR, conductor.CC_ACSR, conductor.CC_AAC, conductor.CC_CUWELD}
+
+// conductorFlags Flags to select the conductor
+type conductorFlags struct {
+	name    string // Id or name in catalog
+	catalog string // Catalog directory
+	file    string // Conductor JSON file
+}
+
+func (cf *conductorFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&cf.name, "conductor", "", "conductor `id` or name in catalog")
+	fs.StringVar(&cf.catalog, "catalog", os.Getenv("CONDUCTOR_CATALOG"),
+		"catalog repository `dir` (default $CONDUCTOR_CATALOG)")
+	fs.StringVar(&cf.file, "file", "", "conductor JSON `file`")
+}
+
+// get Returns the selected conductor
+func (cf *conductorFlags) get() (*conductor.Conductor, error) {
+	if (cf.name == "") == (cf.file == "") {
+		return nil, usagef("one of -conductor or -file is required")
+	}
+	var repo conductor.Repository
+	if cf.catalog != "" {
+		r, err := conductor.OpenFileRepository(cf.catalog)
+		if err != nil {
+			return nil, err
+		}
+		repo = r
+	}
+	if cf.file != "" {
+		return readConductorFile(cf.file, repo)
+	}
+	if repo == nil {
+		return nil, usagef("-catalog or CONDUCTOR_CATALOG is required with -conductor")
+	}
+	return findConductor(repo, cf.name)
+}
+
+// findConductor Returns conductor with id name or, if there is none, the only conductor
+// named name (case insensitive)
+func findConductor(repo conductor.Repository, name string) (*conductor.Conductor, error) {
+	c, err := repo.GetConductor(name)
+	if err == nil || !errors.Is(err, conductor.ErrNotFound) {
+		return c, err
+	}
+	list, err := repo.ListConductors()
+	if err != nil {
+		return nil, err
+	}
+	var found []*conductor.Conductor
+	for _, x := range list {
+		if strings.EqualFold(x.Name(), name) {
+			found = append(found, x)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, &conductor.NotFoundError{Op: "catalog", Kind: "conductor", Id: name}
+	case 1:
+		return found[0], nil
+	}
+	return nil, &conductor.ConfigError{Op: "catalog", Field: "conductor " + name,
+		Msg: "matches " + strconv.Itoa(len(found)) + " conductors (use id)"}
+}
+
+// readConductorFile Returns the conductor of JSON file path, a conductor.ConductorDoc.
+// Category ids are resolved in repo (if not nil) and then in builtinCategories.
+func readConductorFile(path string, repo conductor.Repository) (*conductor.Conductor, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d conductor.ConductorDoc
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, &conductor.ConfigError{Op: "conductor file", Field: path, Msg: err.Error()}
+	}
+	c, err := d.Conductor(func(id string) (*conductor.Category, error) {
+		if repo != nil {
+			if cat, err := repo.GetCategory(id); err == nil {
+				return cat, nil
+			}
+		}
+		for _, x := range builtinCategories {
+			if x.Id() == id {
+				return x, nil
+			}
+		}
+		return nil, &conductor.NotFoundError{Op: "conductor file", Kind: "category", Id: id}
+	})
+	if err != nil {
+		return nil, &conductor.OpError{Op: "conductor file " + path, Err: err}
+	}
+	return c, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// weatherFlags Flags for CurrentCalc conditions
+type weatherFlags struct {
+	cond      conductor.Conditions
+	formula   string
+	deltaTemp float64
+	file      string // Weather CSV file
+}
+
+func (wf *weatherFlags) register(fs *flag.FlagSet, csv bool) {
+	d := conductor.CONDITIONS_DEFAULT
+	fs.Float64Var(&wf.cond.Altitude, "altitude", d.Altitude, "altitude [m]")
+	fs.Float64Var(&wf.cond.AirVelocity, "wind", d.AirVelocity, "velocity of air stream [ft/s]")
+	fs.Var(windMsFlag{&wf.cond.AirVelocity}, "wind-ms",
+		"velocity of air stream [m/s] (excludes -wind)")
+	fs.Float64Var(&wf.cond.WindAngle, "wind-angle", d.WindAngle,
+		"angle between air stream and conductor axis [°]")
+	fs.Float64Var(&wf.cond.SunEffect, "sun", d.SunEffect, "sun effect factor (0 to 1)")
+	fs.Float64Var(&wf.cond.Emissivity, "emissivity", d.Emissivity, "emissivity (0 to 1)")
+	fs.StringVar(&wf.formula, "formula", conductor.CF_IEEE, "heat balance model ("+
+		strings.Join(conductor.ModelNames(), ", ")+")")
+	fs.Float64Var(&wf.deltaTemp, "delta", 0.01, "temperature tolerance of tc and ta [°C]")
+	if csv {
+		fs.StringVar(&wf.file, "weather", "", "weather CSV `file` with a header row; columns "+
+			"ta, tc, ic, altitude, wind or wind-ms, wind-angle, sun and emissivity "+
+			"(missing columns take flag values)")
+	}
+}
+
+// check Returns error if flags set in the command line are mutually exclusive
+func (wf *weatherFlags) check(set map[string]bool) error {
+	if set["wind"] && set["wind-ms"] {
+		return usagef("-wind and -wind-ms are mutually exclusive")
+	}
+	return nil
+}
+
+// windMsFlag Flag value that sets a velocity of air stream [ft/s] from m/s
+type windMsFlag struct {
+	v *float64 // Velocity of air stream [ft/s]
+}
+
+func (f windMsFlag) String() string {
+	if f.v == nil {
+		return ""
+	}
+	v, _ := conductor.Convert(*f.v, conductor.UN_FT_S, conductor.UN_M_S)
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func (f windMsFlag) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f.v, err = conductor.Convert(v, conductor.UN_M_S, conductor.UN_FT_S)
+	return err
+}
+
+// currentCalc Returns *CurrentCalc for c with the conditions of the flags
+func (wf *weatherFlags) currentCalc(c *conductor.Conductor) (*conductor.CurrentCalc, error) {
+	m, err := conductor.GetModel(wf.formula)
+	if err != nil {
+		return nil, usagef("invalid -formula %q", wf.formula)
+	}
+	cc, err := conductor.NewCurrentCalc(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := cc.SetConditions(wf.cond); err != nil {
+		return nil, err
+	}
+	cc.SetModel(m)
+	if err := cc.SetDeltaTemp(wf.deltaTemp); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// Columns of a weather CSV file
+var weatherColumns = []string{"ta", "tc", "ic", "altitude", "wind", "wind-ms", "wind-angle",
+	"sun", "emissivity"}
+
+// readWeather Returns batch inputs of the weather CSV file and its columns. Columns not
+// present take the value of base and the conditions of the flags.
+func (wf *weatherFlags) readWeather(base conductor.BatchInput) ([]conductor.BatchInput,
+	map[string]bool, error) {
+	f, err := os.Open(wf.file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, &conductor.ConfigError{Op: "weather file", Field: wf.file,
+			Msg: err.Error()}
+	}
+	index := map[string]int{}
+	columns := map[string]bool{}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		valid := false
+		for _, c := range weatherColumns {
+			valid = valid || c == h
+		}
+		if !valid {
+			return nil, nil, &conductor.ConfigError{Op: "weather file", Field: "column " + h,
+				Msg: "is unknown"}
+		}
+		index[h] = i
+		columns[h] = true
+	}
+	if columns["wind"] && columns["wind-ms"] {
+		return nil, nil, &conductor.ConfigError{Op: "weather file",
+			Field: "columns wind and wind-ms", Msg: "are mutually exclusive"}
+	}
+
+	var inputs []conductor.BatchInput
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return inputs, columns, nil
+		}
+		if err != nil {
+			return nil, nil, &conductor.ConfigError{Op: "weather file", Field: wf.file,
+				Msg: err.Error()}
+		}
+		in := base
+		cond := wf.cond
+		var windMs float64
+		for _, x := range []struct {
+			col string
+			v   *float64
+		}{
+			{"ta", &in.Ta}, {"tc", &in.Tc}, {"ic", &in.Ic}, {"altitude", &cond.Altitude},
+			{"wind", &cond.AirVelocity}, {"wind-ms", &windMs}, {"wind-angle", &cond.WindAngle},
+			{"sun", &cond.SunEffect}, {"emissivity", &cond.Emissivity},
+		} {
+			i, ok := index[x.col]
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(rec[i]), 64)
+			if err != nil {
+				return nil, nil, &conductor.ConfigError{Op: "weather file",
+					Field: "line " + strconv.Itoa(line) + " " + x.col, Msg: "is not a number"}
+			}
+			*x.v = v
+		}
+		if columns["wind-ms"] {
+			cond.AirVelocity, _ = conductor.Convert(windMs, conductor.UN_M_S, conductor.UN_FT_S)
+		}
+		in.Conditions = &cond
+		inputs = append(inputs, in)
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+// parseList Returns the values of a comma separated list of numbers
+func parseList(name string, s string) ([]float64, error) {
+	var list []float64
+	for _, x := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		if err != nil {
+			return nil, usagef("invalid -%s value %q", name, x)
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
@@ -0,0 +1,116 @@
+// Copyright Cristian Echeverría Rabí
+
+// Command conductor calculates ampacity, conductor and ambient temperatures, rating
+// tables and sag-tension of high voltage conductors.
+//
+// Usage:
+//
+//	conductor <command> [flags]
+//
+// Commands:
+//
+//	current       Current [A] for ambient temperature -ta and conductor temperature -tc
+//	tc            Conductor temperature [°C] for ambient temperature -ta and current -ic
+//	ta            Ambient temperature [°C] for conductor temperature -tc and current -ic
+//	rating-table  Current [A] for a range of ambient temperatures and conductor temperatures
+//	sag           Horizontal tension [kg] and sag [m] of a level span for temperatures
+//	serve         HTTP/JSON rating service (package server) for the conductors of a catalog
+//
+// The conductor is taken by id or name from a catalog (a repository directory created by
+// conductor.OpenFileRepository, flag -catalog or environment variable CONDUCTOR_CATALOG),
+// or from a JSON file (flag -file). Weather comes from flags or, for current, tc and ta,
+// from a CSV file with one record per row (flag -weather). Results are written as text,
+// CSV or JSON (flag -format). Run "conductor <command> -h" for the flags of a command.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Exit codes
+const (
+	exitOK    = 0 // Success
+	exitError = 1 // Calculation or input data error
+	exitUsage = 2 // Invalid command line
+)
+
+// commands Subcommands by name
+var commands = map[string]func(args []string, stdout io.Writer, stderr io.Writer) error{
+	"current":      runCurrent,
+	"tc":           runTc,
+	"ta":           runTa,
+	"rating-table": runRatingTable,
+	"sag":          runSag,
+	"serve":        runServe,
+}
+
+const usage = `Usage: conductor <command> [flags]
+
+Commands:
+  current       Current [A] for ambient temperature -ta and conductor temperature -tc
+  tc            Conductor temperature [°C] for ambient temperature -ta and current -ic
+  ta            Ambient temperature [°C] for conductor temperature -tc and current -ic
+  rating-table  Current [A] for a range of ambient temperatures and conductor temperatures
+  sag           Horizontal tension [kg] and sag [m] of a level span for temperatures
+  serve         HTTP/JSON rating service for the conductors of a catalog
+
+Run "conductor <command> -h" for the flags of a command.
+`
+
+//----------------------------------------------------------------------------------------
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run Executes the command line args and returns the exit code
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "conductor: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	if err := cmd(args[1:], stdout, stderr); err != nil {
+		if u, ok := err.(*usageError); ok {
+			if u.msg != "" {
+				fmt.Fprintf(stderr, "conductor %s: %s\n", args[0], u.msg)
+			}
+			if u.help {
+				return exitOK
+			}
+			return exitUsage
+		}
+		fmt.Fprintf(stderr, "conductor %s: %v\n", args[0], err)
+		return exitError
+	}
+	return exitOK
+}
+
+//----------------------------------------------------------------------------------------
+
+// usageError Invalid command line. help is true when the user asked for help.
+type usageError struct {
+	msg  string
+	help bool
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef Returns *usageError with formatted message
+func usagef(format string, a ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, a...)}
+}
@@ -0,0 +1,210 @@
+// Copyright Cristian Echeverría Rabí
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cer1969/go-conductor"
+)
+
+const flintJSON = `{"id": "FLINT", "name": "AAAC 740,8 MCM FLINT", "category": "AAAC",
+	"diameter": 25.17, "area": 375.4, "weight": 1.035, "strength": 11250, "r25": 0.08936,
+	"hcap": 1e-10}`
+
+// tempFile Writes data to file name in a temporary directory and returns its path
+func tempFile(t *testing.T, name string, data string) string {
+	dir, err := ioutil.TempDir("", "conductor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runArgs(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_Usage(t *testing.T) {
+	if code, _, _ := runArgs(); code != exitUsage {
+		t.Errorf("exitUsage expected got %d", code)
+	}
+	if code, _, _ := runArgs("help"); code != exitOK {
+		t.Errorf("exitOK expected got %d", code)
+	}
+	if code, _, _ := runArgs("none"); code != exitUsage {
+		t.Errorf("exitUsage expected got %d", code)
+	}
+	if code, _, _ := runArgs("current", "-h"); code != exitOK {
+		t.Errorf("exitOK expected got %d", code)
+	}
+	if code, _, stderr := runArgs("current", "-ta", "25", "-tc", "75"); code != exitUsage ||
+		!strings.Contains(stderr, "-conductor or -file") {
+		t.Errorf("exitUsage expected got %d %s", code, stderr)
+	}
+	file := tempFile(t, "flint.json", flintJSON)
+	if code, _, stderr := runArgs("current", "-file", file, "-ta", "25"); code != exitUsage ||
+		!strings.Contains(stderr, "-tc is required") {
+		t.Errorf("exitUsage expected got %d %s", code, stderr)
+	}
+	if code, _, _ := runArgs("current", "-file", file, "-ta", "25", "-tc", "75",
+		"-format", "xml"); code != exitUsage {
+		t.Errorf("exitUsage expected got %d", code)
+	}
+	if code, _, _ := runArgs("current", "-file", file, "-ta", "95", "-tc", "75"); code != exitError {
+		t.Errorf("exitError expected got %d", code)
+	}
+}
+
+func Test_Current(t *testing.T) {
+	file := tempFile(t, "flint.json", flintJSON)
+	code, stdout, stderr := runArgs("current", "-file", file, "-ta", "25", "-tc", "50",
+		"-format", "json")
+	if code != exitOK {
+		t.Fatal(stderr)
+	}
+	var out struct {
+		Conductor string
+		Results   []map[string]float64
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Conductor != "FLINT" || len(out.Results) != 1 {
+		t.Fatalf("Wrong output %s", stdout)
+	}
+	if r := out.Results[0]; r["ta"] != 25 || r["tc"] != 50 || r["current"] < 517.6 ||
+		r["current"] > 517.8 {
+		t.Errorf("Current 517.7 expected got %v", r)
+	}
+
+	code, stdout, _ = runArgs("tc", "-file", file, "-ta", "25", "-ic", "517.7")
+	if code != exitOK || !strings.Contains(stdout, "50.0") {
+		t.Errorf("Tc 50.0 expected got %s", stdout)
+	}
+
+	// 0.6096 m/s = 2 ft/s
+	code, stdout, _ = runArgs("tc", "-file", file, "-ta", "25", "-ic", "517.7", "-wind-ms",
+		"0.6096")
+	if code != exitOK || !strings.Contains(stdout, "50.0") {
+		t.Errorf("Tc 50.0 expected got %s", stdout)
+	}
+	for _, args := range [][]string{{"tc", "-ta", "25", "-ic", "517.7"}, {"rating-table"}} {
+		args = append(args, "-file", file, "-wind", "2", "-wind-ms", "0.6096")
+		code, _, stderr := runArgs(args...)
+		if code != exitUsage || !strings.Contains(stderr, "mutually exclusive") {
+			t.Errorf("%v: exitUsage expected got %d %s", args, code, stderr)
+		}
+	}
+}
+
+func Test_Weather(t *testing.T) {
+	file := tempFile(t, "flint.json", flintJSON)
+	weather := tempFile(t, "weather.csv", "ta,wind, sun\n25,2,1\n30,0,0\n95,2,1\n")
+	code, stdout, stderr := runArgs("current", "-file", file, "-weather", weather, "-tc", "75",
+		"-format", "csv")
+	if code != exitError || !strings.Contains(stderr, "1 of 3 rows failed") {
+		t.Errorf("exitError expected got %d %s", code, stderr)
+	}
+	recs, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 4 || recs[0][2] != "current" || recs[0][len(recs[0])-1] != "error" {
+		t.Fatalf("Wrong output %s", stdout)
+	}
+	if recs[2][0] != "30" || recs[2][4] != "0" || recs[3][2] != "" || recs[3][8] == "" {
+		t.Errorf("Wrong output %s", stdout)
+	}
+
+	weather = tempFile(t, "weather-ms.csv", "ta,wind-ms\n30,0.6096\n")
+	code, stdout, _ = runArgs("current", "-file", file, "-weather", weather, "-tc", "75",
+		"-format", "csv")
+	recs, _ = csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if code != exitOK || len(recs) != 2 || recs[1][4] != "2" {
+		t.Errorf("Wind 2 ft/s expected got %d %s", code, stdout)
+	}
+
+	both := tempFile(t, "both.csv", "ta,wind,wind-ms\n30,2,0.6096\n")
+	code, _, stderr = runArgs("current", "-file", file, "-weather", both, "-tc", "75")
+	if code != exitError || !strings.Contains(stderr, "mutually exclusive") {
+		t.Errorf("exitError expected got %d %s", code, stderr)
+	}
+
+	bad := tempFile(t, "bad.csv", "ta,rain\n25,1\n")
+	if code, _, _ := runArgs("current", "-file", file, "-weather", bad, "-tc", "75"); code !=
+		exitError {
+		t.Errorf("exitError expected got %d", code)
+	}
+}
+
+func Test_RatingTable(t *testing.T) {
+	file := tempFile(t, "flint.json", flintJSON)
+	code, stdout, stderr := runArgs("rating-table", "-file", file, "-tc", "50,75",
+		"-ta-min", "0", "-ta-max", "40", "-ta-step", "10", "-format", "csv")
+	if code != exitOK {
+		t.Fatal(stderr)
+	}
+	recs, _ := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if len(recs) != 6 || strings.Join(recs[0], ",") != "ta,current_50,current_75" {
+		t.Fatalf("Wrong output %s", stdout)
+	}
+	if recs[3][0] != "20" {
+		t.Errorf("ta 20 expected got %s", recs[3][0])
+	}
+}
+
+func Test_Sag(t *testing.T) {
+	file := tempFile(t, "flint.json", flintJSON)
+	code, stdout, stderr := runArgs("sag", "-file", file, "-span", "300", "-h0-pct", "20",
+		"-t", "15,50")
+	if code != exitOK {
+		t.Fatal(stderr)
+	}
+	if !strings.Contains(stdout, "2250.00") || !strings.Contains(stdout, "6.81") {
+		t.Errorf("Wrong output %s", stdout)
+	}
+	if code, _, _ := runArgs("sag", "-file", file, "-span", "300"); code != exitUsage {
+		t.Errorf("exitUsage expected got %d", code)
+	}
+}
+
+func Test_Catalog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repo, err := conductor.OpenFileRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.PutCategory(conductor.CC_AAAC)
+	repo.PutConductor(conductor.NewConductor("AAAC 740,8 MCM FLINT", conductor.CC_AAAC, 25.17,
+		375.4, 1.035, 11250, 0.08936, 1e-10, "FLINT"))
+
+	for _, name := range []string{"FLINT", "aaac 740,8 mcm flint"} {
+		code, stdout, stderr := runArgs("current", "-catalog", dir, "-conductor", name, "-ta",
+			"25", "-tc", "50")
+		if code != exitOK || !strings.Contains(stdout, "517.") {
+			t.Errorf("Current 517.7 expected got %d %s %s", code, stdout, stderr)
+		}
+	}
+	if code, _, _ := runArgs("current", "-catalog", dir, "-conductor", "NONE", "-ta", "25",
+		"-tc", "50"); code != exitError {
+		t.Errorf("exitError expected got %d", code)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright Cristian Echeverría Rabí
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"text/tabwriter"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Output formats
+const (
+	formatText = "text"
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+// outputFlags Flags for results
+type outputFlags struct {
+	format string
+}
+
+func (of *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&of.format, "format", formatText, "output `format` text, csv or json")
+}
+
+func (of *outputFlags) check() error {
+	switch of.format {
+	case formatText, formatCSV, formatJSON:
+		return nil
+	}
+	return usagef("invalid -format %q", of.format)
+}
+
+//----------------------------------------------------------------------------------------
+
+// table Results of a command. errs is nil or has the error of each row.
+type table struct {
+	conductor string      // Conductor id or name
+	columns   []string    // Column names
+	rows      [][]float64 // Values of each row (NaN if not calculated)
+	errs      []error     // Error of each row
+}
+
+// add Appends a row with its error
+func (t *table) add(row []float64, err error) {
+	if err != nil && t.errs == nil {
+		t.errs = make([]error, len(t.rows), len(t.rows)+1)
+	}
+	if t.errs != nil {
+		t.errs = append(t.errs, err)
+	}
+	t.rows = append(t.rows, row)
+}
+
+// rowErr Returns error of row i
+func (t *table) rowErr(i int) error {
+	if t.errs == nil {
+		return nil
+	}
+	return t.errs[i]
+}
+
+// write Writes t to w in format. Returns error if writing fails or if any row has an
+// error (the error of the row is part of the output).
+func (t *table) write(w io.Writer, format string) error {
+	var err error
+	switch format {
+	case formatCSV:
+		err = t.writeCSV(w)
+	case formatJSON:
+		err = t.writeJSON(w)
+	default:
+		err = t.writeText(w)
+	}
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, e := range t.errs {
+		if e != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, len(t.rows))
+	}
+	return nil
+}
+
+func (t *table) writeText(w io.Writer) error {
+	fmt.Fprintf(w, "Conductor: %s\n\n", t.conductor)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, c := range t.columns {
+		fmt.Fprintf(tw, "%s\t", c)
+	}
+	fmt.Fprintln(tw)
+	for _, row := range t.rows {
+		for _, v := range row {
+			if math.IsNaN(v) {
+				fmt.Fprint(tw, "-\t")
+			} else {
+				fmt.Fprintf(tw, "%.2f\t", v)
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for i := range t.errs {
+		if err := t.errs[i]; err != nil {
+			fmt.Fprintf(w, "row %d: %v\n", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (t *table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := t.columns
+	if t.errs != nil {
+		header = append(header[:len(header):len(header)], "error")
+	}
+	cw.Write(header)
+	for i, row := range t.rows {
+		rec := make([]string, len(row), len(header))
+		for j, v := range row {
+			if !math.IsNaN(v) {
+				rec[j] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		if t.errs != nil {
+			msg := ""
+			if err := t.rowErr(i); err != nil {
+				msg = err.Error()
+			}
+			rec = append(rec, msg)
+		}
+		cw.Write(rec)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (t *table) writeJSON(w io.Writer) error {
+	results := make([]map[string]interface{}, len(t.rows))
+	for i, row := range t.rows {
+		m := make(map[string]interface{}, len(row)+1)
+		for j, v := range row {
+			if math.IsNaN(v) {
+				m[t.columns[j]] = nil
+			} else {
+				m[t.columns[j]] = v
+			}
+		}
+		if err := t.rowErr(i); err != nil {
+			m["error"] = err.Error()
+		}
+		results[i] = m
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Conductor string                   `json:"conductor"`
+		Results   []map[string]interface{} `json:"results"`
+	}{t.conductor, results})
+}
@@ -0,0 +1,164 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+//----------------------------------------------------------------------------------------
+
+// Conditions Weather and surface conditions used by CurrentCalc for calculations.
+// Conditions is a value: copies are independent and safe to share between goroutines.
+type Conditions struct {
+	Altitude    float64 // Altitude [m] (required >= 0)
+	AirVelocity float64 // Velocity of air stream [ft/seg] (required >= 0)
+	WindAngle   float64 // Angle between air stream and conductor axis [°] (0 to 90)
+	SunEffect   float64 // Sun effect factor (0 to 1)
+	Emissivity  float64 // Emissivity (0 to 1)
+}
+
+// CONDITIONS_DEFAULT Conditions of a CurrentCalc returned by NewCurrentCalc
+var CONDITIONS_DEFAULT = Conditions{300.0, 2.0, 90.0, 1.0, 0.5}
+
+// Check Returns error if any value of c is out of range
+func (c Conditions) Check() error {
+	var cc CurrentCalc
+	return cc.setConditions("Conditions.Check", c)
+}
+
+//----------------------------------------------------------------------------------------
+
+// Conditions Returns the current weather and surface conditions of cc
+func (cc *CurrentCalc) Conditions() Conditions {
+	return Conditions{cc.altitude, cc.airVelocity, cc.windAngle, cc.sunEffect, cc.emissivity}
+}
+
+// SetConditions Sets all weather and surface conditions. cc is not modified on error.
+func (cc *CurrentCalc) SetConditions(c Conditions) error {
+	x := *cc
+	if err := x.setConditions("CurrentCalc.SetConditions", c); err != nil {
+		return err
+	}
+	*cc = x
+	return nil
+}
+
+// setConditions Sets conditions c using setters. Errors are prefixed by op.
+func (cc *CurrentCalc) setConditions(op string, c Conditions) error {
+	for _, set := range []func() error{
+		func() error { return cc.SetAltitude(c.Altitude) },
+		func() error { return cc.SetAirVelocity(c.AirVelocity) },
+		func() error { return cc.SetWindAngle(c.WindAngle) },
+		func() error { return cc.SetSunEffect(c.SunEffect) },
+		func() error { return cc.SetEmissivity(c.Emissivity) },
+	} {
+		if err := set(); err != nil {
+			return &OpError{op, err}
+		}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------------------
+// With* methods return a modified copy of CurrentCalc and leave the receiver untouched.
+// Calculation methods (Current, Tc, Ta, HeatBalance, ...) only read the receiver, so a
+// CurrentCalc that is not modified with Set* methods can serve concurrent requests and
+// each request can use its own conditions through a With* copy.
+
+// WithConditions Returns a copy of cc using conditions c
+func (cc *CurrentCalc) WithConditions(c Conditions) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.setConditions("CurrentCalc.WithConditions", c); err != nil {
+		return nil, err
+	}
+	return &x, nil
+}
+
+// WithAltitude Returns a copy of cc with altitude h [m]
+func (cc *CurrentCalc) WithAltitude(h float64) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetAltitude(h); err != nil {
+		return nil, &OpError{"CurrentCalc.WithAltitude", err}
+	}
+	return &x, nil
+}
+
+// WithAirVelocity Returns a copy of cc with velocity of air stream v [ft/seg]
+func (cc *CurrentCalc) WithAirVelocity(v float64) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetAirVelocity(v); err != nil {
+		return nil, &OpError{"CurrentCalc.WithAirVelocity", err}
+	}
+	return &x, nil
+}
+
+// WithWindAngle Returns a copy of cc with angle a [°] between air stream and conductor
+func (cc *CurrentCalc) WithWindAngle(a float64) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetWindAngle(a); err != nil {
+		return nil, &OpError{"CurrentCalc.WithWindAngle", err}
+	}
+	return &x, nil
+}
+
+// WithSunEffect Returns a copy of cc with sun effect factor se
+func (cc *CurrentCalc) WithSunEffect(se float64) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetSunEffect(se); err != nil {
+		return nil, &OpError{"CurrentCalc.WithSunEffect", err}
+	}
+	return &x, nil
+}
+
+// WithEmissivity Returns a copy of cc with emissivity e
+func (cc *CurrentCalc) WithEmissivity(e float64) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetEmissivity(e); err != nil {
+		return nil, &OpError{"CurrentCalc.WithEmissivity", err}
+	}
+	return &x, nil
+}
+
+// WithFormula Returns a copy of cc using formula f
+func (cc *CurrentCalc) WithFormula(f string) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetFormula(f); err != nil {
+		return nil, &OpError{"CurrentCalc.WithFormula", err}
+	}
+	return &x, nil
+}
+
+// WithModel Returns a copy of cc using heat balance model m
+func (cc *CurrentCalc) WithModel(m HeatBalanceModel) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetModel(m); err != nil {
+		return nil, &OpError{"CurrentCalc.WithModel", err}
+	}
+	return &x, nil
+}
+
+// WithDeltaTemp Returns a copy of cc with temperature difference t [°C]
+func (cc *CurrentCalc) WithDeltaTemp(t float64) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetDeltaTemp(t); err != nil {
+		return nil, &OpError{"CurrentCalc.WithDeltaTemp", err}
+	}
+	return &x, nil
+}
+
+// WithIterMax Returns a copy of cc with maximum iterations n for Tc and Ta
+func (cc *CurrentCalc) WithIterMax(n int) (*CurrentCalc, error) {
+	x := *cc
+	if err := x.SetIterMax(n); err != nil {
+		return nil, &OpError{"CurrentCalc.WithIterMax", err}
+	}
+	return &x, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// WithConditions Returns a copy of oi whose CurrentCalc uses conditions c
+func (oi *OperatingItem) WithConditions(c Conditions) (*OperatingItem, error) {
+	cc, err := oi.currentCalc.WithConditions(c)
+	if err != nil {
+		return nil, &OpError{"OperatingItem.WithConditions", err}
+	}
+	return &OperatingItem{cc, oi.tempMaxOp, oi.nsc}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"sync"
+	"testing"
+)
+
+func Test_CurrentCalc_Conditions(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	if cc.Conditions() != CONDITIONS_DEFAULT {
+		t.Error("CONDITIONS_DEFAULT expected")
+	}
+
+	c := Conditions{1000, 3, 45, 0.5, 0.9}
+	if err := cc.SetConditions(c); err != nil {
+		t.Error(err)
+	}
+	if cc.Conditions() != c {
+		t.Error("Error en SetConditions")
+	}
+
+	bad := c
+	bad.SunEffect = 1.5
+	if err := bad.Check(); err == nil {
+		t.Error("SunEffect>1 error expected")
+	}
+	if err := cc.SetConditions(bad); err == nil {
+		t.Error("SunEffect>1 error expected")
+	}
+	if cc.Conditions() != c {
+		t.Error("CurrentCalc must not be modified on error")
+	}
+}
+
+func Test_CurrentCalc_With(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+
+	c := Conditions{1000, 3, 45, 0.5, 0.9}
+	x, err := cc.WithConditions(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Conditions() != c || x.Conductor() != cc.Conductor() {
+		t.Error("Error en WithConditions")
+	}
+	if cc.Conditions() != CONDITIONS_DEFAULT {
+		t.Error("CurrentCalc must not be modified")
+	}
+
+	x, _ = cc.WithAltitude(500)
+	x, _ = x.WithAirVelocity(1)
+	x, _ = x.WithWindAngle(30)
+	x, _ = x.WithSunEffect(0)
+	x, _ = x.WithEmissivity(0.8)
+	x, _ = x.WithFormula(CF_CLASSIC)
+	x, _ = x.WithDeltaTemp(0.001)
+	if x.Conditions() != (Conditions{500, 1, 30, 0, 0.8}) {
+		t.Error("Error en With*")
+	}
+	if x.Formula() != CF_CLASSIC || x.DeltaTemp() != 0.001 {
+		t.Error("Error en With*")
+	}
+	if cc.Conditions() != CONDITIONS_DEFAULT || cc.Formula() != CF_IEEE {
+		t.Error("CurrentCalc must not be modified")
+	}
+
+	if _, err := cc.WithAltitude(-1); err == nil {
+		t.Error("Altitude<0 error expected")
+	}
+	if _, err := cc.WithAirVelocity(-1); err == nil {
+		t.Error("AirVelocity<0 error expected")
+	}
+	if _, err := cc.WithWindAngle(91); err == nil {
+		t.Error("WindAngle>90 error expected")
+	}
+	if _, err := cc.WithSunEffect(-1); err == nil {
+		t.Error("SunEffect<0 error expected")
+	}
+	if _, err := cc.WithEmissivity(2); err == nil {
+		t.Error("Emissivity>1 error expected")
+	}
+	if _, err := cc.WithDeltaTemp(0); err == nil {
+		t.Error("DeltaTemp=0 error expected")
+	}
+}
+
+func Test_OperatingItem_WithConditions(t *testing.T) {
+	opi, _ := NewOperatingItem(getCurrentCalc(), 50, 2)
+	x, err := opi.WithConditions(Conditions{1000, 3, 45, 0.5, 0.9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.TempMaxOp() != 50 || x.Nsc() != 2 || x.CurrentCalc() == opi.CurrentCalc() {
+		t.Error("Error en WithConditions")
+	}
+	if opi.CurrentCalc().Conditions() != CONDITIONS_DEFAULT {
+		t.Error("OperatingItem must not be modified")
+	}
+	if _, err := opi.WithConditions(Conditions{Altitude: -1}); err == nil {
+		t.Error("Invalid conditions error expected")
+	}
+}
+
+func Test_CurrentCalc_Concurrent(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	velocities := []float64{0, 1, 2, 4, 8}
+	want := make([]float64, len(velocities))
+	for i, v := range velocities {
+		x, _ := cc.WithAirVelocity(v)
+		want[i], _ = x.Tc(25, 500)
+	}
+
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		for i, v := range velocities {
+			wg.Add(1)
+			go func(i int, v float64) {
+				defer wg.Done()
+				x, _ := cc.WithAirVelocity(v)
+				if got, _ := x.Tc(25, 500); got != want[i] {
+					t.Errorf("Tc %f expected got %f", want[i], got)
+				}
+			}(i, v)
+		}
+	}
+	wg.Wait()
+}
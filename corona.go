@@ -0,0 +1,191 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+//----------------------------------------------------------------------------------------
+
+// CoronaConditions Operating and weather conditions for LineGeometry.Corona
+type CoronaConditions struct {
+	Voltage  float64 // Line to line voltage [kV rms] (required > 0)
+	Altitude float64 // Altitude [m] (required >= 0)
+	Ta       float64 // Ambient temperature [°C] (TA_MIN to TA_MAX)
+	M        float64 // Surface irregularity factor of conductors (0 to 1, 0.8 to 0.9 stranded)
+	RainRate float64 // Rain rate for foul weather [mm/h] (required > 0)
+	X        float64 // Lateral position of the observer, 1.5 m above ground [m]
+}
+
+// CoronaResult Corona performance of a LineGeometry. Phase values are in phase order.
+type CoronaResult struct {
+	Gradient []float64 // Maximum surface gradient of each phase [kV/cm rms]
+	Onset    []float64 // Corona onset gradient of each phase (Peek) [kV/cm rms]
+	FairLoss float64   // Fair weather corona loss of all phases (Peek) [kW/km]
+	FoulLoss float64   // Foul weather corona loss of all phases (BPA) [kW/km]
+	AN       float64   // Foul weather audible noise L50 at the observer (BPA) [dBA]
+	RI       float64   // Fair weather radio interference at 0.5 MHz at the observer (CIGRE) [dB(µV/m)]
+}
+
+// RelativeAirDensity Returns the air density relative to 101.3 kPa and 20°C
+// altitude float64 : Altitude [m]
+// ta       float64 : Ambient temperature [°C]
+func RelativeAirDensity(altitude float64, ta float64) float64 {
+	return math.Exp(-altitude/8150) * 293 / (273 + ta)
+}
+
+// OnsetGradient Returns the corona onset gradient of Peek's law [kV/cm rms]
+// diameter float64 : Conductor diameter [mm]
+// delta    float64 : Relative air density
+// m        float64 : Surface irregularity factor
+func OnsetGradient(diameter float64, delta float64, m float64) float64 {
+	r := diameter / 20 // [cm]
+	return 30 / math.Sqrt2 * m * delta * (1 + 0.301/math.Sqrt(delta*r))
+}
+
+//----------------------------------------------------------------------------------------
+
+// SurfaceGradient Returns the maximum surface gradient of the subconductors of each phase
+// [kV/cm rms] for balanced line to line voltage [kV rms] with phase order a, b, c in every
+// circuit. Charges come from the capacitance matrix and the gradient of bundles from the
+// Markt-Mengele method.
+func (lg *LineGeometry) SurfaceGradient(voltage float64) ([]float64, error) {
+	return lg.surfaceGradient("LineGeometry.SurfaceGradient", voltage)
+}
+
+func (lg *LineGeometry) surfaceGradient(op string, voltage float64) ([]float64, error) {
+	if voltage <= 0 {
+		return nil, &RangeError{op, "voltage", "<=", "0", 0, voltage}
+	}
+	c, err := lg.capacitance(op)
+	if err != nil {
+		return nil, err
+	}
+	v := make([]complex128, lg.nphases)
+	for i := range v {
+		v[i] = cmplx.Rect(voltage*1000/math.Sqrt(3), -2*math.Pi/3*float64(i%3)) // [V]
+	}
+	g := make([]float64, lg.nphases)
+	for i := range g {
+		var q complex128 // [C/m]
+		for j := range v {
+			q += c[i][j] / 1000 * v[j]
+		}
+		w := &lg.wires[i]
+		r := w.Conductor.diameter / 2000 // [m]
+		avg := cmplx.Abs(q) / (2 * math.Pi * EPS0 * float64(w.Nsc) * r)
+		gmax := avg
+		if w.Nsc > 1 {
+			gmax *= 1 + float64(w.Nsc-1)*r/(w.Spacing/(2*math.Sin(math.Pi/float64(w.Nsc))))
+		}
+		g[i] = gmax / 1e5 // [kV/cm]
+	}
+	return g, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// Corona Returns surface and onset gradients, corona loss, audible noise and radio
+// interference of the phases. Shield wires do not produce corona. These are empirical
+// estimates for conductor selection, not a replacement for test line data.
+func (lg *LineGeometry) Corona(cond CoronaConditions) (*CoronaResult, error) {
+	op := "LineGeometry.Corona"
+	if cond.Altitude < 0 {
+		return nil, &RangeError{op, "Altitude", "<", "0", 0, cond.Altitude}
+	}
+	if cond.Ta < TA_MIN {
+		return nil, &RangeError{op, "Ta", "<", "TA_MIN", TA_MIN, cond.Ta}
+	}
+	if cond.Ta > TA_MAX {
+		return nil, &RangeError{op, "Ta", ">", "TA_MAX", TA_MAX, cond.Ta}
+	}
+	if cond.M <= 0 {
+		return nil, &RangeError{op, "M", "<=", "0", 0, cond.M}
+	}
+	if cond.M > 1 {
+		return nil, &RangeError{op, "M", ">", "1", 1, cond.M}
+	}
+	if cond.RainRate <= 0 {
+		return nil, &RangeError{op, "RainRate", "<=", "0", 0, cond.RainRate}
+	}
+	g, err := lg.surfaceGradient(op, cond.Voltage)
+	if err != nil {
+		return nil, err
+	}
+	delta := RelativeAirDensity(cond.Altitude, cond.Ta)
+	vp := cond.Voltage / math.Sqrt(3)
+	alt := cond.Altitude / 300 // Altitude correction of AN, RI and foul loss [dB]
+	cr := &CoronaResult{Gradient: g, Onset: make([]float64, lg.nphases)}
+	an := 0.0
+	ri := make([]float64, lg.nphases)
+	for i := range g {
+		w := &lg.wires[i]
+		d := w.Conductor.diameter / 10 // [cm]
+		n := float64(w.Nsc)
+		cr.Onset[i] = OnsetGradient(w.Conductor.diameter, delta, cond.M)
+
+		// Peek with the disruptive voltage of the phase
+		if v0 := vp * cr.Onset[i] / g[i]; vp > v0 {
+			cr.FairLoss += 241 / delta * (lg.frequency + 25) *
+				math.Sqrt(w.BundleRadius()/lg.phaseSpacing(i)) * (vp - v0) * (vp - v0) * 1e-5
+		}
+
+		// BPA foul weather loss [dB above 1 W/m]
+		k1, k2 := 19.0, 10.0
+		if w.Nsc > 4 {
+			k1 = 13
+		}
+		if cond.RainRate > 3.6 {
+			k2 = 14
+		}
+		cl := 14.2 + 65*math.Log10(g[i]/18.8) + 40*math.Log10(d/3.51) + k1*math.Log10(n/4) +
+			k2*math.Log10(cond.RainRate/1.676) + alt
+		cr.FoulLoss += math.Pow(10, cl/10) // [W/m] = [kW/km]
+
+		// BPA audible noise and CIGRE radio interference
+		dist := math.Hypot(w.X-cond.X, w.Y-1.5)
+		a := 120*math.Log10(g[i]) + 55*math.Log10(d) - 11.4*math.Log10(dist) - 115.4 + alt
+		if w.Nsc >= 3 {
+			a = 120*math.Log10(g[i]) + 55*math.Log10(d) + 26.4*math.Log10(n) -
+				11.4*math.Log10(dist) - 128.4 + alt
+		}
+		an += math.Pow(10, a/10)
+		ri[i] = 3.5*g[i] + 6*d - 33*math.Log10(dist/20) - 30 + alt
+	}
+	cr.AN = 10 * math.Log10(an)
+	cr.RI = combineRI(ri)
+	return cr, nil
+}
+
+// phaseSpacing Returns the geometric mean distance of phase i to the other phases of its
+// circuit [m]
+func (lg *LineGeometry) phaseSpacing(i int) float64 {
+	a := &lg.wires[i]
+	p := 1.0
+	for k := 3 * (i / 3); k < 3*(i/3)+3; k++ {
+		if k != i {
+			p *= math.Hypot(a.X-lg.wires[k].X, a.Y-lg.wires[k].Y)
+		}
+	}
+	return math.Sqrt(p)
+}
+
+// combineRI Returns the radio interference of the line from phase values (CIGRE): the
+// highest if it exceeds the others by 3 dB or more, otherwise the mean of the two highest
+// plus 1.5 dB
+func combineRI(ri []float64) float64 {
+	first, second := math.Inf(-1), math.Inf(-1)
+	for _, x := range ri {
+		if x > first {
+			first, second = x, first
+		} else if x > second {
+			second = x
+		}
+	}
+	if first-second >= 3 {
+		return first
+	}
+	return (first+second)/2 + 1.5
+}
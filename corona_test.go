@@ -0,0 +1,112 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// getCoronaConditions Returns conditions for a 500 kV line
+func getCoronaConditions() CoronaConditions {
+	return CoronaConditions{500, 300, 20, 0.85, 1, 30}
+}
+
+// get500kV Returns a 500 kV horizontal line with bundles of nsc DRAKE conductors
+func get500kV(nsc int) *LineGeometry {
+	s := getStranding()
+	c := s.Conductor(CC_ACSR)
+	lg, _ := NewLineGeometry([]LineWire{
+		{c, -11, 22, nsc, 0.45, s.GMR()},
+		{c, 0, 22, nsc, 0.45, s.GMR()},
+		{c, 11, 22, nsc, 0.45, s.GMR()},
+	}, nil, 50)
+	return lg
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Corona_Onset(t *testing.T) {
+	if d := RelativeAirDensity(0, 20); d != 1 {
+		t.Errorf("1 expected got %f", d)
+	}
+	// Smooth 2 cm diameter conductor at sea level: 21.2*(1 + 0.301) kV/cm
+	if g := OnsetGradient(20, 1, 1); math.Abs(g-30/math.Sqrt2*1.301) > 1e-9 {
+		t.Errorf("%f expected got %f", 30/math.Sqrt2*1.301, g)
+	}
+	if OnsetGradient(28, RelativeAirDensity(3000, 20), 0.85) >= OnsetGradient(28, 1, 0.85) {
+		t.Error("Lower onset gradient at altitude expected")
+	}
+}
+
+func Test_Corona_Gradient(t *testing.T) {
+	g1, err := get500kV(3).SurfaceGradient(500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Center phase has the highest gradient
+	if g1[1] <= g1[0] || math.Abs(g1[0]-g1[2]) > 1e-9 || g1[1] < 14 || g1[1] > 20 {
+		t.Errorf("Gradient about 17 kV/cm expected got %v", g1)
+	}
+	g2, _ := get500kV(4).SurfaceGradient(500)
+	if g2[1] >= g1[1] {
+		t.Errorf("Lower gradient with 4 subconductors expected got %v", g2)
+	}
+}
+
+func Test_Corona_Performance(t *testing.T) {
+	c3, err := get500kV(3).Corona(getCoronaConditions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c4, _ := get500kV(4).Corona(getCoronaConditions())
+	if c4.FoulLoss >= c3.FoulLoss || c4.AN >= c3.AN || c4.RI >= c3.RI {
+		t.Errorf("Better performance with 4 subconductors expected got %+v %+v", c3, c4)
+	}
+	if c3.AN < 40 || c3.AN > 70 || c3.RI < 30 || c3.RI > 70 {
+		t.Errorf("Plausible AN and RI expected got %+v", c3)
+	}
+	cond := getCoronaConditions()
+	cond.X = 100
+	far, _ := get500kV(3).Corona(cond)
+	if far.AN >= c3.AN || far.RI >= c3.RI {
+		t.Errorf("Lower AN and RI far from the line expected got %+v", far)
+	}
+
+	bad := []func(*CoronaConditions){
+		func(c *CoronaConditions) { c.Voltage = 0 },
+		func(c *CoronaConditions) { c.Altitude = -1 },
+		func(c *CoronaConditions) { c.Ta = 100 },
+		func(c *CoronaConditions) { c.M = 0 },
+		func(c *CoronaConditions) { c.RainRate = 0 },
+	}
+	for i, f := range bad {
+		cond := getCoronaConditions()
+		f(&cond)
+		if _, err := get500kV(3).Corona(cond); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%d: range error expected got %v", i, err)
+		}
+	}
+}
+
+func Test_Corona_CombineRI(t *testing.T) {
+	if ri := combineRI([]float64{50, 45, 40}); ri != 50 {
+		t.Errorf("50 expected got %f", ri)
+	}
+	if ri := combineRI([]float64{50, 49, 40}); ri != 51 {
+		t.Errorf("51 expected got %f", ri)
+	}
+}
+
+func Example_LineGeometry_Corona() {
+	for _, nsc := range []int{3, 4} {
+		cr, _ := get500kV(nsc).Corona(getCoronaConditions())
+		fmt.Printf("%d x DRAKE: %.1f kV/cm (onset %.1f) foul loss %.1f kW/km AN %.1f dBA "+
+			"RI %.1f dB\n", nsc, cr.Gradient[1], cr.Onset[1], cr.FoulLoss, cr.AN, cr.RI)
+	}
+	// Output:
+	// 3 x DRAKE: 18.0 kV/cm (onset 21.9) foul loss 7.5 kW/km AN 45.1 dBA RI 43.0 dB
+	// 4 x DRAKE: 15.2 kV/cm (onset 21.9) foul loss 4.2 kW/km AN 39.3 dBA RI 33.3 dB
+}
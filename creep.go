@@ -0,0 +1,249 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Constants for creep
+//
+//	CREEP_HOURS = 87600  Hours of 10 years, the usual creep horizon
+const (
+	CREEP_HOURS = 87600.0
+)
+
+//----------------------------------------------------------------------------------------
+
+// CreepModel Creep strain of a conductor at constant stress and temperature. Strain must
+// increase with hours.
+type CreepModel interface {
+	// Strain Returns the creep strain [mm/mm] after hours at stress [kg/mm²] and
+	// conductor temperature [°C]
+	Strain(stress float64, temperature float64, hours float64) (float64, error)
+}
+
+// LogTimeCreep Creep model fitted to creep tests (CIGRE / IEEE):
+// ε [µm/m] = K·e^(Phi·T)·σ^Alpha·t^Mu, σ [MPa], T [°C], t [h]
+type LogTimeCreep struct {
+	K     float64 // Constant of the conductor (required > 0)
+	Phi   float64 // Temperature coefficient [1/°C]
+	Alpha float64 // Stress exponent (required > 0)
+	Mu    float64 // Time exponent (required 0 < Mu < 1)
+}
+
+// Strain Returns the creep strain [mm/mm] (see CreepModel)
+func (lc LogTimeCreep) Strain(stress float64, temperature float64, hours float64) (float64,
+	error) {
+	op := "LogTimeCreep.Strain"
+	if lc.K <= 0 {
+		return math.NaN(), &RangeError{op, "K", "<=", "0", 0, lc.K}
+	}
+	if lc.Alpha <= 0 {
+		return math.NaN(), &RangeError{op, "Alpha", "<=", "0", 0, lc.Alpha}
+	}
+	if lc.Mu <= 0 {
+		return math.NaN(), &RangeError{op, "Mu", "<=", "0", 0, lc.Mu}
+	}
+	if lc.Mu >= 1 {
+		return math.NaN(), &RangeError{op, "Mu", ">=", "1", 1, lc.Mu}
+	}
+	if err := checkCreepArgs(op, stress, temperature, hours); err != nil {
+		return math.NaN(), err
+	}
+	return lc.K * math.Exp(lc.Phi*temperature) * math.Pow(stress*gravity, lc.Alpha) *
+		math.Pow(hours, lc.Mu) * 1e-6, nil
+}
+
+// PolynomialCreep Creep model from the stress-strain-creep test polynomials of the
+// Aluminum Association method: σ = A0 + A1·ε + A2·ε² + A3·ε³ + A4·ε⁴, σ [kg/mm²], ε [%].
+// The creep strain of 10 years is the strain of the Creep curve minus the strain of the
+// Initial curve at the same stress; other durations scale with (t/CREEP_HOURS)^Mu. The
+// curves are valid at the test temperature, so temperature is not used.
+type PolynomialCreep struct {
+	Initial [5]float64 // Coefficients of the initial loading curve
+	Creep   [5]float64 // Coefficients of the 10 years creep curve
+	Mu      float64    // Time exponent (0 uses 0.16)
+}
+
+// Strain Returns the creep strain [mm/mm] (see CreepModel)
+func (pc PolynomialCreep) Strain(stress float64, temperature float64, hours float64) (float64,
+	error) {
+	op := "PolynomialCreep.Strain"
+	if err := checkCreepArgs(op, stress, temperature, hours); err != nil {
+		return math.NaN(), err
+	}
+	ei, err := invertCurve(op, "Initial", pc.Initial, stress)
+	if err != nil {
+		return math.NaN(), err
+	}
+	ec, err := invertCurve(op, "Creep", pc.Creep, stress)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return math.Max(ec-ei, 0) / 100 * math.Pow(hours/CREEP_HOURS, orDefault(pc.Mu, 0.16)), nil
+}
+
+// invertCurve Returns the strain [%] of curve a at stress, searched between 0 and 2%
+func invertCurve(op string, name string, a [5]float64, stress float64) (float64, error) {
+	f := func(e float64) float64 {
+		return a[0] + e*(a[1]+e*(a[2]+e*(a[3]+e*a[4]))) - stress
+	}
+	fa, fb := f(0), f(2)
+	if fa >= 0 {
+		return 0, nil
+	}
+	if fb < 0 {
+		return math.NaN(), &RangeError{op, "stress", ">", name + " curve at 2%", stress - fb,
+			stress}
+	}
+	e, iter, ok := brent(f, 0, 2, fa, fb, 1e-9, ITER_MAX, false)
+	if !ok {
+		return math.NaN(), &ConvergenceError{op, iter}
+	}
+	return e, nil
+}
+
+// checkCreepArgs Returns error if arguments of CreepModel.Strain are out of range
+func checkCreepArgs(op string, stress float64, temperature float64, hours float64) error {
+	if stress < 0 {
+		return &RangeError{op, "stress", "<", "0", 0, stress}
+	}
+	if temperature < TC_MIN {
+		return &RangeError{op, "temperature", "<", "TC_MIN", TC_MIN, temperature}
+	}
+	if temperature > TC_MAX {
+		return &RangeError{op, "temperature", ">", "TC_MAX", TC_MAX, temperature}
+	}
+	if hours < 0 {
+		return &RangeError{op, "hours", "<", "0", 0, hours}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// CreepStep One step of a tension history
+type CreepStep struct {
+	Hours       float64 // Duration of the step [h] (required > 0)
+	Tension     float64 // Conductor tension [kg] (required >= 0)
+	Temperature float64 // Conductor temperature [°C]
+}
+
+// CreepStrain Returns the creep strain [mm/mm] accumulated by conductor c over a tension
+// history with the strain hardening rule: each step continues from the time that gives
+// the accumulated strain at the stress and temperature of the step. Steps that cannot
+// reach the accumulated strain do not add creep.
+func CreepStrain(model CreepModel, c *Conductor, history []CreepStep) (float64, error) {
+	op := "CreepStrain"
+	if model == nil {
+		return math.NaN(), &ConfigError{op, "model", "== nil"}
+	}
+	if c == nil {
+		return math.NaN(), &ConfigError{op, "Conductor", "== nil"}
+	}
+	if c.area <= 0 {
+		return math.NaN(), &RangeError{op, "Conductor.Area", "<=", "0", 0, c.area}
+	}
+	strain := 0.0
+	for i, cs := range history {
+		field := "step " + strconv.Itoa(i)
+		if cs.Hours <= 0 {
+			return math.NaN(), &RangeError{op, field + " Hours", "<=", "0", 0, cs.Hours}
+		}
+		stress := cs.Tension / c.area
+		te, err := equivalentTime(model, stress, cs.Temperature, strain)
+		if err != nil {
+			return math.NaN(), &OpError{op + " " + field, err}
+		}
+		if math.IsInf(te, 1) {
+			continue
+		}
+		e, err := model.Strain(stress, cs.Temperature, te+cs.Hours)
+		if err != nil {
+			return math.NaN(), &OpError{op + " " + field, err}
+		}
+		strain = math.Max(strain, e)
+	}
+	return strain, nil
+}
+
+// equivalentTime Returns the hours at stress and temperature that give strain, +Inf if
+// strain is not reached in 1000 times CREEP_HOURS
+func equivalentTime(model CreepModel, stress float64, temperature float64,
+	strain float64) (float64, error) {
+	if strain == 0 {
+		return 0, nil
+	}
+	var serr error
+	f := func(h float64) float64 {
+		e, err := model.Strain(stress, temperature, h)
+		if err != nil {
+			serr = err
+		}
+		return e - strain
+	}
+	hmax := 1000 * CREEP_HOURS
+	fa, fb := f(0), f(hmax)
+	if serr != nil {
+		return math.NaN(), serr
+	}
+	if fb < 0 {
+		return math.Inf(1), nil
+	}
+	if fa >= 0 {
+		return 0, nil
+	}
+	h, iter, ok := brent(f, 0, hmax, fa, fb, 1e-3, ITER_MAX, false)
+	if serr != nil {
+		return math.NaN(), serr
+	}
+	if !ok {
+		return math.NaN(), &ConvergenceError{"equivalentTime", iter}
+	}
+	return h, nil
+}
+
+// EquivalentCreepStrain Returns the creep strain [mm/mm] of the equivalent temperature
+// method, Category.Creep·Category.Coefexp
+func EquivalentCreepStrain(cat *Category) float64 {
+	return cat.creep * cat.coefexp
+}
+
+//----------------------------------------------------------------------------------------
+
+// FinalTension Returns the horizontal tension [kg] at conductor temperature t [°C] after
+// permanent creep strain [mm/mm] (see CreepStrain and EquivalentCreepStrain)
+func (st *SagTensionCalc) FinalTension(t float64, strain float64) (float64, error) {
+	return st.finalTension("SagTensionCalc.FinalTension", t, strain)
+}
+
+func (st *SagTensionCalc) finalTension(op string, t float64, strain float64) (float64, error) {
+	if t < TC_MIN {
+		return math.NaN(), &RangeError{op, "t", "<", "TC_MIN", TC_MIN, t}
+	}
+	if t > TC_MAX {
+		return math.NaN(), &RangeError{op, "t", ">", "TC_MAX", TC_MAX, t}
+	}
+	if strain < 0 {
+		return math.NaN(), &RangeError{op, "strain", "<", "0", 0, strain}
+	}
+	h, iter, ok := st.tension(t, st.conductor.weight, strain)
+	if !ok {
+		return math.NaN(), &ConvergenceError{op, iter}
+	}
+	return h, nil
+}
+
+// FinalSag Returns the mid span sag [m] at conductor temperature t [°C] after permanent
+// creep strain [mm/mm]
+func (st *SagTensionCalc) FinalSag(t float64, strain float64) (float64, error) {
+	h, err := st.finalTension("SagTensionCalc.FinalSag", t, strain)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return st.SagAt(h), nil
+}
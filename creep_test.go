@@ -0,0 +1,163 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getLogTimeCreep() LogTimeCreep {
+	return LogTimeCreep{0.3, 0.01, 1.4, 0.16}
+}
+
+func getPolynomialCreep() PolynomialCreep {
+	// Linear initial curve with E = 5600 kg/mm² and creep curve with E = 4000 kg/mm²
+	return PolynomialCreep{[5]float64{0, 56, 0, 0, 0}, [5]float64{0, 40, 0, 0, 0}, 0}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Creep_LogTime(t *testing.T) {
+	lc := getLogTimeCreep()
+	e, err := lc.Strain(4, 20, CREEP_HOURS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := 0.3 * math.Exp(0.2) * math.Pow(4*gravity, 1.4) * math.Pow(CREEP_HOURS, 0.16) * 1e-6
+	if math.Abs(e-x) > 1e-15 {
+		t.Errorf("%g expected got %g", x, e)
+	}
+	hot, _ := lc.Strain(4, 60, CREEP_HOURS)
+	high, _ := lc.Strain(5, 20, CREEP_HOURS)
+	if hot <= e || high <= e {
+		t.Errorf("More creep with temperature and stress expected got %g %g", hot, high)
+	}
+	for _, m := range []LogTimeCreep{{0, 0, 1, 0.2}, {1, 0, 0, 0.2}, {1, 0, 1, 0}, {1, 0, 1, 1}} {
+		if _, err := m.Strain(4, 20, 1); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%+v: range error expected got %v", m, err)
+		}
+	}
+}
+
+func Test_Creep_Polynomial(t *testing.T) {
+	pc := getPolynomialCreep()
+	e, err := pc.Strain(4, 20, CREEP_HOURS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x := 4.0/4000 - 4.0/5600; math.Abs(e-x) > 1e-9 {
+		t.Errorf("%g expected got %g", x, e)
+	}
+	e1, _ := pc.Strain(4, 20, CREEP_HOURS/10)
+	if x := e * math.Pow(0.1, 0.16); math.Abs(e1-x) > 1e-9 {
+		t.Errorf("%g expected got %g", x, e1)
+	}
+	if _, err := pc.Strain(100, 20, CREEP_HOURS); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error beyond 2%% strain expected got %v", err)
+	}
+}
+
+func Test_Creep_History(t *testing.T) {
+	c := getDrake()
+	lc := getLogTimeCreep()
+	tension := 2850.0
+	e10, _ := lc.Strain(tension/c.Area(), 15, CREEP_HOURS)
+
+	// Constant history in several steps equals a single step
+	history := make([]CreepStep, 10)
+	for i := range history {
+		history[i] = CreepStep{CREEP_HOURS / 10, tension, 15}
+	}
+	e, err := CreepStrain(lc, c, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(e-e10)/e10 > 1e-6 {
+		t.Errorf("%g expected got %g", e10, e)
+	}
+
+	// A heavy load step adds creep; a light one afterwards does not remove it
+	heavy := []CreepStep{{1000, 2 * tension, -10}, {CREEP_HOURS - 1000, tension, 15}}
+	eh, _ := CreepStrain(lc, c, heavy)
+	if eh <= e10 {
+		t.Errorf("More creep than %g expected got %g", e10, eh)
+	}
+	light := []CreepStep{{CREEP_HOURS, tension, 15}, {1000, 0.1 * tension, 15}}
+	if el, _ := CreepStrain(lc, c, light); math.Abs(el-e10)/e10 > 1e-6 {
+		t.Errorf("%g expected got %g", e10, el)
+	}
+	if e0, _ := CreepStrain(lc, c, nil); e0 != 0 {
+		t.Errorf("0 expected got %g", e0)
+	}
+}
+
+func Test_Creep_Sag(t *testing.T) {
+	c := getDrake()
+	st, _ := NewSagTensionCalc(c, 350, 15, 2850)
+	initial, _ := st.Sag(75)
+	if s, _ := st.FinalSag(75, 0); s != initial {
+		t.Errorf("%f expected got %f", initial, s)
+	}
+	strain, _ := CreepStrain(getLogTimeCreep(), c,
+		[]CreepStep{{CREEP_HOURS, 2850, 15}})
+	final, err := st.FinalSag(75, strain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if final <= initial {
+		t.Errorf("Sag greater than %f expected got %f", initial, final)
+	}
+
+	// Creep strain is equivalent to a temperature increase strain/coefexp
+	dt := strain / c.Category().Coefexp()
+	if s, _ := st.Sag(75 + dt); math.Abs(s-final) > 1e-6 {
+		t.Errorf("%f expected got %f", s, final)
+	}
+	se, _ := st.FinalSag(75, EquivalentCreepStrain(c.Category()))
+	if s, _ := st.Sag(75 + c.Category().Creep()); math.Abs(s-se) > 1e-6 {
+		t.Errorf("Equivalent temperature method %f expected got %f", s, se)
+	}
+	if h, _ := st.FinalTension(75, strain); math.Abs(st.SagAt(h)-final) > 1e-12 {
+		t.Errorf("Tension of final sag expected got %f", h)
+	}
+}
+
+func Test_Creep_Errors(t *testing.T) {
+	c := getDrake()
+	lc := getLogTimeCreep()
+	if _, err := CreepStrain(nil, c, nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	if _, err := CreepStrain(lc, nil, nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	for _, cs := range []CreepStep{{0, 2850, 15}, {1, -1, 15}, {1, 2850, TC_MAX + 1}} {
+		if _, err := CreepStrain(lc, c, []CreepStep{cs}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%+v: range error expected got %v", cs, err)
+		}
+	}
+	st, _ := NewSagTensionCalc(c, 350, 15, 2850)
+	if _, err := st.FinalSag(75, -1e-4); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	if _, err := st.FinalTension(TC_MIN-1, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+func Example_CreepStrain() {
+	c := getDrake()
+	st, _ := NewSagTensionCalc(c, 350, 15, 2850)
+	history := []CreepStep{{CREEP_HOURS - 48, 2850, 15}, {48, 4500, -10}}
+	strain, _ := CreepStrain(getLogTimeCreep(), c, history)
+	initial, _ := st.Sag(75)
+	final, _ := st.FinalSag(75, strain)
+	fmt.Printf("Creep %.0f µm/m (%.1f °C)\n", strain*1e6, strain/c.Category().Coefexp())
+	fmt.Printf("Sag at 75°C: initial %.2f m, final %.2f m\n", initial, final)
+	// Output:
+	// Creep 660 µm/m (34.5 °C)
+	// Sag at 75°C: initial 11.04 m, final 12.23 m
+}
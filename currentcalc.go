@@ -12,192 +12,172 @@ import (
 // c *Conductor: *Conductor instance
 func NewCurrentCalc(conductor *Conductor) (*CurrentCalc, error) {
 	if conductor == nil {
-		return nil, &ValueError{"NewCurrentCalc: Conductor == nil"}
+		return nil, &ConfigError{"NewCurrentCalc", "Conductor", "== nil"}
 	}
 	if conductor.category == nil {
-		return nil, &ValueError{"NewCurrentCalc: Conductor.Category == nil"}
+		return nil, &ConfigError{"NewCurrentCalc", "Conductor.Category", "== nil"}
 	}
 	if conductor.diameter <= 0 {
-		return nil, &ValueError{"NewCurrentCalc: Conductor.Diameter <= 0"}
+		return nil, &RangeError{"NewCurrentCalc", "Conductor.Diameter", "<=", "0", 0, conductor.diameter}
 	}
 	if conductor.r25 <= 0 {
-		return nil, &ValueError{"NewCurrentCalc: Conductor.R25 <= 0"}
+		return nil, &RangeError{"NewCurrentCalc", "Conductor.R25", "<=", "0", 0, conductor.r25}
 	}
 	if conductor.category.alpha <= 0 {
-		return nil, &ValueError{"NewCurrentCalc: Conductor.Category.Alpha <= 0"}
+		return nil, &RangeError{"NewCurrentCalc", "Conductor.Category.Alpha", "<=", "0", 0,
+			conductor.category.alpha}
 	}
 	if conductor.category.alpha >= 1 {
-		return nil, &ValueError{"NewCurrentCalc: Conductor.Category.Alpha >=1"}
+		return nil, &RangeError{"NewCurrentCalc", "Conductor.Category.Alpha", ">=", "1", 1,
+			conductor.category.alpha}
 	}
-	return &CurrentCalc{conductor, 300.0, 2.0, 1.0, 0.5, CF_IEEE, 0.01}, nil
+	return &CurrentCalc{conductor, 300.0, 2.0, 90.0, 1.0, 0.5, CF_IEEE, ieeeModel, 0.01,
+		ITER_MAX}, nil
 }
 
 //----------------------------------------------------------------------------------------
 
 // CurrentCalc Object to calculate conductor current and temperatures.
 type CurrentCalc struct {
-	conductor   *Conductor // *Conductor instance
-	altitude    float64    // Altitude [m] = 300.0
-	airVelocity float64    // Velocity of air stream [ft/seg] =   2.0
-	sunEffect   float64    // Sun effect factor (0 to 1) = 1.0
-	emissivity  float64    // Emissivity (0 to 1) = 0.5
-	formula     string     // Define formula for current calculation = CF_IEEE
-	deltaTemp   float64    // Temperature difference to determine equality [°C] = 0.0001
+	conductor   *Conductor       // *Conductor instance
+	altitude    float64          // Altitude [m] = 300.0
+	airVelocity float64          // Velocity of air stream [ft/seg] =   2.0
+	windAngle   float64          // Angle between air stream and conductor axis [°] = 90.0
+	sunEffect   float64          // Sun effect factor (0 to 1) = 1.0
+	emissivity  float64          // Emissivity (0 to 1) = 0.5
+	formula     string           // Name of model = CF_IEEE
+	model       HeatBalanceModel // Heat balance model for current calculation
+	deltaTemp   float64          // Temperature difference to determine equality [°C] = 0.0001
+	iterMax     int              // Maximum iterations for Tc and Ta = ITER_MAX
 }
 
 func (cc *CurrentCalc) Resistance(tc float64) (float64, error) {
 	if tc < TC_MIN {
-		return math.NaN(), &ValueError{"CurrentCalc.Resistance: tc < TC_MIN"}
+		return math.NaN(), &RangeError{"CurrentCalc.Resistance", "tc", "<", "TC_MIN", TC_MIN, tc}
 	}
 	if tc > TC_MAX {
-		return math.NaN(), &ValueError{"CurrentCalc.Resistance: tc > TC_MAX"}
+		return math.NaN(), &RangeError{"CurrentCalc.Resistance", "tc", ">", "TC_MAX", TC_MAX, tc}
 	}
 	return cc.conductor.r25 * (1 + cc.conductor.category.alpha*(tc-25.0)), nil
 }
 
 func (cc *CurrentCalc) Current(ta float64, tc float64) (float64, error) {
 	if ta < TA_MIN {
-		return math.NaN(), &ValueError{"CurrentCalc.Current: ta < TA_MIN"}
+		return math.NaN(), &RangeError{"CurrentCalc.Current", "ta", "<", "TA_MIN", TA_MIN, ta}
 	}
 	if ta > TA_MAX {
-		return math.NaN(), &ValueError{"CurrentCalc.Current: ta > TA_MAX"}
+		return math.NaN(), &RangeError{"CurrentCalc.Current", "ta", ">", "TA_MAX", TA_MAX, ta}
 	}
 	if tc < TC_MIN {
-		return math.NaN(), &ValueError{"CurrentCalc.Current: tc < TC_MIN"}
+		return math.NaN(), &RangeError{"CurrentCalc.Current", "tc", "<", "TC_MIN", TC_MIN, tc}
 	}
 	if tc > TC_MAX {
-		return math.NaN(), &ValueError{"CurrentCalc.Current: tc > TC_MAX"}
+		return math.NaN(), &RangeError{"CurrentCalc.Current", "tc", ">", "TC_MAX", TC_MAX, tc}
 	}
 	if ta >= tc {
 		return 0, nil
 	}
 
-	D := cc.conductor.diameter / 25.4                                       // Diámetro en pulgadas
-	Pb := math.Pow(10, (1.880813592 - cc.altitude/18336.0))                 // Presión barométrica en cmHg
-	V := cc.airVelocity * 3600                                              // Vel. viento en pies/hora
-	res, _ := cc.Resistance(tc)                                             // No necesito verificar error porque el valor de tc ya se verificó
-	Rc := res * 0.0003048                                                   // Resistencia en ohm/pies
-	Tm := 0.5 * (tc + ta)                                                   // Temperatura media
-	Rf := 0.2901577 * Pb / (273 + Tm)                                       // Densidad rel.aire [lb/ft^3]
-	Uf := 0.04165 + 0.000111*Tm                                             // Viscosidad abs. aire [lb/(ft x hora)]
-	Kf := 0.00739 + 0.0000227*Tm                                            // Coef. conductividad term. aire [Watt/(ft x °C)]
-	Qc := 0.283 * math.Sqrt(Rf) * math.Pow(D, 0.75) * math.Pow(tc-ta, 1.25) // watt/ft
-
-	if V != 0 {
-		factor := D * Rf * V / Uf
-		Qc1 := 0.1695 * Kf * (tc - ta) * math.Pow(factor, 0.6)
-		Qc2 := Kf * (tc - ta) * (1.01 + 0.371*math.Pow(factor, 0.52))
-		if cc.formula == CF_IEEE {
-			// IEEE criteria
-			Qc = math.Max(Qc, Qc1)
-			Qc = math.Max(Qc, Qc2)
-		} else {
-			// CLASSIC criteria
-			if factor < 12000 {
-				Qc = Qc2
-			} else {
-				Qc = Qc1
-			}
-		}
-	}
-	LK := math.Pow((tc+273)/100, 4)
-	MK := math.Pow((ta+273)/100, 4)
-	Qr := 0.138 * D * cc.emissivity * (LK - MK)
-	Qs := 3.87 * D * cc.sunEffect
-
-	if (Qc + Qr) < Qs {
-		return 0, nil
-	} else {
-		return math.Sqrt((Qc + Qr - Qs) / Rc), nil
-	}
+	var hb HeatBalance
+	cc.heatBalance(ta, tc, &hb)
+	return hb.Current, nil
 }
 
 func (cc *CurrentCalc) Tc(ta float64, ic float64) (float64, error) {
+	tc, _, err := cc.TcIter(ta, ic)
+	return tc, err
+}
+
+// TcIter Returns conductor temperature for ambient temperature ta and current ic, and the
+// number of iterations used by the solver. The result is within deltaTemp and not below
+// the exact value.
+func (cc *CurrentCalc) TcIter(ta float64, ic float64) (float64, int, error) {
 	if ta < TA_MIN {
-		return math.NaN(), &ValueError{"CurrentCalc.Tc: ta < TA_MIN"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Tc", "ta", "<", "TA_MIN", TA_MIN, ta}
 	}
 	if ta > TA_MAX {
-		return math.NaN(), &ValueError{"CurrentCalc.Tc: ta > TA_MAX"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Tc", "ta", ">", "TA_MAX", TA_MAX, ta}
 	}
 
 	icmax, _ := cc.Current(ta, TC_MAX) // No debe haber error: ta está verificado
 	if ic < 0 {
-		return math.NaN(), &ValueError{"CurrentCalc.Tc: ic < 0"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Tc", "ic", "<", "0", 0, ic}
 	}
 	if ic > icmax {
-		return math.NaN(), &ValueError{"CurrentCalc.Tc: ic > Imax (TC_MAX)"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Tc", "ic", ">", "Imax (TC_MAX)", icmax, ic}
 	}
 
-	var tcmin, tcmax, tcmed, icmed float64
-	var err error
-	tcmin = ta
-	tcmax = TC_MAX
-	cuenta := 0
-
-	for (tcmax - tcmin) > cc.deltaTemp {
-		tcmed = 0.5 * (tcmin + tcmax)
-		icmed, err = cc.Current(ta, tcmed)
-		if err != nil {
-			return math.NaN(), &ValueError{"CurrentCalc.Tc: " + err.Error()}
-		}
-		if icmed > ic {
-			tcmax = tcmed
-		} else {
-			tcmin = tcmed
-		}
-		cuenta += 1
-		//		if cuenta > ITER_MAX {
-		//			return math.NaN(), &ValueError{"CurrentCalc.Tc: ITERA MAX exeeded"}
-		//		}
+	// Balance de calor Qc + Qr - Qs - Rc*ic^2, creciente con tc
+	var hb HeatBalance
+	f := func(tc float64) float64 {
+		cc.heatBalance(ta, tc, &hb)
+		return hb.Qc + hb.Qr - hb.Qs - hb.Rc*ic*ic
+	}
+	fa := f(ta)
+	if fa >= 0 {
+		return ta, 1, nil
 	}
-	return tcmed, nil
+	fb := f(TC_MAX)
+	if fb <= 0 {
+		return TC_MAX, 2, nil
+	}
+	tc, iter, ok := brent(f, ta, TC_MAX, fa, fb, cc.deltaTemp, cc.iterMax, true)
+	if !ok {
+		return math.NaN(), iter + 2, &ConvergenceError{"CurrentCalc.Tc", iter + 2}
+	}
+	return tc, iter + 2, nil
 }
 
 func (cc *CurrentCalc) Ta(tc float64, ic float64) (float64, error) {
+	ta, _, err := cc.TaIter(tc, ic)
+	return ta, err
+}
+
+// TaIter Returns ambient temperature for conductor temperature tc and current ic, and the
+// number of iterations used by the solver. The result is within deltaTemp and not above
+// the exact value.
+func (cc *CurrentCalc) TaIter(tc float64, ic float64) (float64, int, error) {
 	if tc < TC_MIN {
-		return math.NaN(), &ValueError{"CurrentCalc.Ta: tc < TC_MIN"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Ta", "tc", "<", "TC_MIN", TC_MIN, tc}
 	}
 	if tc > TC_MAX {
-		return math.NaN(), &ValueError{"CurrentCalc.Ta: tc > TC_MAX"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Ta", "tc", ">", "TC_MAX", TC_MAX, tc}
 	}
 
 	imin, _ := cc.Current(TA_MAX, tc) // No debe haber error: tc está verificado
 	imax, _ := cc.Current(TA_MIN, tc) // No debe haber error: tc está verificado
 	if ic < imin {
-		return math.NaN(), &ValueError{"CurrentCalc.Ta: ic < Imin (TA_MAX)"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Ta", "ic", "<", "Imin (TA_MAX)", imin, ic}
 	}
 	if ic > imax {
-		return math.NaN(), &ValueError{"CurrentCalc.Ta: ic > Imax (TA_MIN)"}
+		return math.NaN(), 0, &RangeError{"CurrentCalc.Ta", "ic", ">", "Imax (TA_MIN)", imax, ic}
 	}
 
-	var tamin, tamax, tamed, icmed float64
-	var err error
-	tamin = TA_MIN
-	tamax = math.Min(TA_MAX, tc)
-
+	tamin := TA_MIN
+	tamax := math.Min(TA_MAX, tc)
 	if tamin > tamax {
-		return 0, nil
+		return 0, 0, nil
 	}
 
-	cuenta := 0
-
-	for (tamax - tamin) > cc.deltaTemp {
-		tamed = 0.5 * (tamin + tamax)
-		icmed, err = cc.Current(tamed, tc)
-		if err != nil {
-			return math.NaN(), &ValueError{"CurrentCalc.Ta: " + err.Error()}
-		}
-		if icmed > ic {
-			tamin = tamed
-		} else {
-			tamax = tamed
-		}
-		cuenta += 1
-		//		if cuenta > ITER_MAX {
-		//			return math.NaN(), &ValueError{"CurrentCalc.Ta: ITERA MAX exeeded"}
-		//		}
+	// Balance de calor Qc + Qr - Qs - Rc*ic^2, decreciente con ta
+	var hb HeatBalance
+	f := func(ta float64) float64 {
+		cc.heatBalance(ta, tc, &hb)
+		return hb.Qc + hb.Qr - hb.Qs - hb.Rc*ic*ic
+	}
+	fa := f(tamin)
+	if fa <= 0 {
+		return tamin, 1, nil
+	}
+	fb := f(tamax)
+	if fb >= 0 {
+		return tamax, 2, nil
 	}
-	return tamed, nil
+	ta, iter, ok := brent(f, tamin, tamax, fa, fb, cc.deltaTemp, cc.iterMax, true)
+	if !ok {
+		return math.NaN(), iter + 2, &ConvergenceError{"CurrentCalc.Ta", iter + 2}
+	}
+	return ta, iter + 2, nil
 }
 
 func (cc *CurrentCalc) Conductor() *Conductor {
@@ -210,7 +190,7 @@ func (cc *CurrentCalc) Altitude() float64 {
 
 func (cc *CurrentCalc) SetAltitude(h float64) error {
 	if h < 0 {
-		return &ValueError{"CurrentCalc.SetAltitude: h < 0"}
+		return &RangeError{"CurrentCalc.SetAltitude", "h", "<", "0", 0, h}
 	}
 	cc.altitude = h
 	return nil
@@ -222,22 +202,37 @@ func (cc *CurrentCalc) AirVelocity() float64 {
 
 func (cc *CurrentCalc) SetAirVelocity(v float64) error {
 	if v < 0 {
-		return &ValueError{"CurrentCalc.SetAirVelocity: v < 0"}
+		return &RangeError{"CurrentCalc.SetAirVelocity", "v", "<", "0", 0, v}
 	}
 	cc.airVelocity = v
 	return nil
 }
 
+func (cc *CurrentCalc) WindAngle() float64 {
+	return cc.windAngle
+}
+
+func (cc *CurrentCalc) SetWindAngle(a float64) error {
+	if a < 0 {
+		return &RangeError{"CurrentCalc.SetWindAngle", "a", "<", "0", 0, a}
+	}
+	if a > 90 {
+		return &RangeError{"CurrentCalc.SetWindAngle", "a", ">", "90", 90, a}
+	}
+	cc.windAngle = a
+	return nil
+}
+
 func (cc *CurrentCalc) SunEffect() float64 {
 	return cc.sunEffect
 }
 
 func (cc *CurrentCalc) SetSunEffect(se float64) error {
 	if se < 0 {
-		return &ValueError{"CurrentCalc.SetSunEffect: se < 0"}
+		return &RangeError{"CurrentCalc.SetSunEffect", "se", "<", "0", 0, se}
 	}
 	if se > 1 {
-		return &ValueError{"CurrentCalc.SetSunEffect: se > 1"}
+		return &RangeError{"CurrentCalc.SetSunEffect", "se", ">", "1", 1, se}
 	}
 	cc.sunEffect = se
 	return nil
@@ -249,10 +244,10 @@ func (cc *CurrentCalc) Emissivity() float64 {
 
 func (cc *CurrentCalc) SetEmissivity(e float64) error {
 	if e < 0 {
-		return &ValueError{"CurrentCalc.SetEmissivity: e < 0"}
+		return &RangeError{"CurrentCalc.SetEmissivity", "e", "<", "0", 0, e}
 	}
 	if e > 1 {
-		return &ValueError{"CurrentCalc.SetEmissivity: e > 1"}
+		return &RangeError{"CurrentCalc.SetEmissivity", "e", ">", "1", 1, e}
 	}
 	cc.emissivity = e
 	return nil
@@ -262,11 +257,33 @@ func (cc *CurrentCalc) Formula() string {
 	return cc.formula
 }
 
-func (cc *CurrentCalc) SetFormula(f string) {
-	if f != CF_CLASSIC {
-		f = CF_IEEE
+// SetFormula Selects the registered model with name f. An empty name selects CF_IEEE.
+// Returns error and keeps the current model if f is not registered.
+func (cc *CurrentCalc) SetFormula(f string) error {
+	m := ieeeModel
+	if f != "" {
+		var err error
+		if m, err = GetModel(f); err != nil {
+			return &OpError{"CurrentCalc.SetFormula", err}
+		}
+	}
+	cc.formula = m.Name()
+	cc.model = m
+	return nil
+}
+
+func (cc *CurrentCalc) Model() HeatBalanceModel {
+	return cc.model
+}
+
+// SetModel Sets heat balance model m. m does not need to be registered.
+func (cc *CurrentCalc) SetModel(m HeatBalanceModel) error {
+	if m == nil {
+		return &ConfigError{"CurrentCalc.SetModel", "model", "== nil"}
 	}
-	cc.formula = f
+	cc.formula = m.Name()
+	cc.model = m
+	return nil
 }
 
 func (cc *CurrentCalc) DeltaTemp() float64 {
@@ -275,8 +292,20 @@ func (cc *CurrentCalc) DeltaTemp() float64 {
 
 func (cc *CurrentCalc) SetDeltaTemp(t float64) error {
 	if t <= 0 {
-		return &ValueError{"CurrentCalc.SetDeltaTemp: t <= 0"}
+		return &RangeError{"CurrentCalc.SetDeltaTemp", "t", "<=", "0", 0, t}
 	}
 	cc.deltaTemp = t
 	return nil
 }
+
+func (cc *CurrentCalc) IterMax() int {
+	return cc.iterMax
+}
+
+func (cc *CurrentCalc) SetIterMax(n int) error {
+	if n < 1 {
+		return &RangeError{"CurrentCalc.SetIterMax", "n", "<", "1", 1, float64(n)}
+	}
+	cc.iterMax = n
+	return nil
+}
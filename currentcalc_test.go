@@ -36,6 +36,9 @@ func Test_CurrentCalc_ConstructorDefaults(t *testing.T) {
 	if cc.AirVelocity() != 2 {
 		t.Error("!=")
 	}
+	if cc.WindAngle() != 90 {
+		t.Error("!=")
+	}
 	if cc.SunEffect() != 1 {
 		t.Error("!=")
 	}
@@ -48,6 +51,9 @@ func Test_CurrentCalc_ConstructorDefaults(t *testing.T) {
 	if cc.DeltaTemp() != 0.01 {
 		t.Error("!=")
 	}
+	if cc.IterMax() != ITER_MAX {
+		t.Error("!=")
+	}
 }
 
 func Test_CurrentCalc_ConstructorConductor(t *testing.T) {
@@ -238,6 +244,42 @@ func Test_CurrentCalc_AirVelocity(t *testing.T) {
 	}
 }
 
+func Test_CurrentCalc_WindAngle(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	x1, _ := cc.Current(25, 50)
+
+	err := cc.SetWindAngle(45.0)
+	if err != nil {
+		t.Error(err)
+	}
+	if cc.windAngle != cc.WindAngle() {
+		t.Error("Error en SetWindAngle")
+	}
+	if cc.WindAngle() != 45.0 {
+		t.Error("Error en SetWindAngle")
+	}
+	x2, _ := cc.Current(25, 50)
+	if x2 >= x1 {
+		t.Error("Current with oblique air stream < perpendicular expected")
+	}
+	err = cc.SetWindAngle(0.0)
+	if err != nil {
+		t.Errorf("WindAngle=0 error not expected: %v", err)
+	}
+	err = cc.SetWindAngle(90.0)
+	if err != nil {
+		t.Errorf("WindAngle=90 error not expected: %v", err)
+	}
+	err = cc.SetWindAngle(-0.001)
+	if err == nil {
+		t.Error("WindAngle<0 error expected")
+	}
+	err = cc.SetWindAngle(90.001)
+	if err == nil {
+		t.Error("WindAngle>90 error expected")
+	}
+}
+
 func Test_CurrentCalc_SunEffect(t *testing.T) {
 	cc, _ := NewCurrentCalc(getConductor())
 
@@ -353,6 +395,29 @@ func Test_CurrentCalc_DeltaTemp(t *testing.T) {
 	}
 }
 
+func Test_CurrentCalc_IterMax(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+
+	err := cc.SetIterMax(100)
+	if err != nil {
+		t.Error(err)
+	}
+	if cc.iterMax != cc.IterMax() {
+		t.Error("Error en SetIterMax")
+	}
+	if cc.IterMax() != 100 {
+		t.Error("Error en SetIterMax")
+	}
+	err = cc.SetIterMax(1)
+	if err != nil {
+		t.Errorf("IterMax=1 error not expected: %v", err)
+	}
+	err = cc.SetIterMax(0)
+	if err == nil {
+		t.Error("IterMax<1 error expected")
+	}
+}
+
 //----------------------------------------------------------------------------------------
 
 func Test_CurrentCalc_Resistance(t *testing.T) {
@@ -546,6 +611,64 @@ func Test_CurrentCalc_Ta(t *testing.T) {
 
 }
 
+func Test_CurrentCalc_TcIter(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	cc.SetDeltaTemp(0.0001)
+
+	// Bisection from ta to TC_MAX would need log2(TC_MAX/deltaTemp) > 24 iterations
+	for _, ic := range []float64{0, 100, 500, 1000, 2000} {
+		tc, iter, err := cc.TcIter(25, ic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if iter > 15 {
+			t.Errorf("ic=%f: iterations <= 15 expected got %d", ic, iter)
+		}
+		cur, _ := cc.Current(25, tc)
+		ta, iter, err := cc.TaIter(tc, cur)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if iter > 15 {
+			t.Errorf("ic=%f: iterations <= 15 expected got %d", ic, iter)
+		}
+		if cur > 0 && math.Abs(ta-25) > cc.deltaTemp {
+			t.Errorf("Ta 25 expected got %f", ta)
+		}
+	}
+
+	// Sun heating with no current
+	tc, _, _ := cc.TcIter(25, 0)
+	if tc <= 25 {
+		t.Errorf("Tc > ta expected with sun effect got %f", tc)
+	}
+	cur, _ := cc.Current(25, tc+0.01)
+	if cur == 0 {
+		t.Error("Current > 0 expected above sun heating temperature")
+	}
+}
+
+func Test_CurrentCalc_TcConvergence(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	cc.SetIterMax(2)
+
+	_, iter, err := cc.TcIter(25, 500)
+	if err == nil {
+		t.Fatal("ConvergenceError expected")
+	}
+	cerr, ok := err.(*ConvergenceError)
+	if !ok {
+		t.Fatalf("*ConvergenceError expected got %T", err)
+	}
+	if cerr.Iter != iter {
+		t.Errorf("Iter %d expected got %d", iter, cerr.Iter)
+	}
+	_, err = cc.Ta(50, 500)
+	if _, ok := err.(*ConvergenceError); !ok {
+		t.Errorf("*ConvergenceError expected got %v", err)
+	}
+}
+
 //----------------------------------------------------------------------------------------
 
 func Example_CurrentCalc_Resistance() {
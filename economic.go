@@ -0,0 +1,144 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// EconomicCandidate Conductor option for SelectConductor
+type EconomicCandidate struct {
+	Conductor  *Conductor  // *Conductor of subconductors
+	Nsc        int         // Number of subconductors per phase (required >= 1)
+	Cost       float64     // Capital cost of the line with this conductor [$/km] (required >= 0)
+	Conditions *Conditions // Weather for rating and losses (nil uses CONDITIONS_DEFAULT)
+}
+
+// EconomicStudy Requirements and economic parameters of SelectConductor
+type EconomicStudy struct {
+	Current   float64    // Required phase current [A] (required > 0)
+	TempMaxOp float64    // Maximum operating temperature for the required rating [°C]
+	Ta        float64    // Ambient temperature for the required rating [°C]
+	Profile   []LoadStep // Yearly load profile, Ic is the phase current [A]
+	Price     float64    // Energy price [$/kWh] (required >= 0)
+	Rate      float64    // Capitalisation rate per year (required > 0)
+	Years     int        // Lifetime of the line [years] (required >= 1)
+}
+
+// EconomicResult Evaluation of a candidate. Costs are per km of a three phase circuit.
+type EconomicResult struct {
+	Candidate    EconomicCandidate // Evaluated candidate
+	Rating       float64           // Phase current at TempMaxOp and Ta [A]
+	Feasible     bool              // Rating >= Current and Tc <= TempMaxOp for the profile
+	Losses       *LossResult       // Losses of a phase over the profile (nil if not feasible)
+	AnnualEnergy float64           // Energy losses of three phases in a year [kWh/km]
+	LossCost     float64           // Present worth of losses over the lifetime [$/km]
+	Total        float64           // Cost + LossCost [$/km] (+Inf if not feasible)
+	Err          error             // Reason of an unfeasible candidate
+}
+
+// PresentWorthFactor Returns the present worth of 1 per year during years with rate
+func PresentWorthFactor(rate float64, years int) float64 {
+	return (1 - math.Pow(1+rate, -float64(years))) / rate
+}
+
+//----------------------------------------------------------------------------------------
+
+// SelectConductor Returns candidates ranked by lifetime cost: capital cost plus the present
+// worth of Joule losses of three phases. Losses of the profile are scaled to 8760 h a
+// year. Candidates with a rating below the required current or exceeding TempMaxOp in any
+// step of the profile are not feasible and go last, in candidates order.
+func SelectConductor(candidates []EconomicCandidate, study EconomicStudy) ([]EconomicResult,
+	error) {
+	op := "SelectConductor"
+	if len(candidates) == 0 {
+		return nil, &ConfigError{op, "candidates", "is empty"}
+	}
+	if study.Current <= 0 {
+		return nil, &RangeError{op, "Current", "<=", "0", 0, study.Current}
+	}
+	if study.TempMaxOp < TC_MIN {
+		return nil, &RangeError{op, "TempMaxOp", "<", "TC_MIN", TC_MIN, study.TempMaxOp}
+	}
+	if study.TempMaxOp > TC_MAX {
+		return nil, &RangeError{op, "TempMaxOp", ">", "TC_MAX", TC_MAX, study.TempMaxOp}
+	}
+	if len(study.Profile) == 0 {
+		return nil, &ConfigError{op, "Profile", "is empty"}
+	}
+	if study.Price < 0 {
+		return nil, &RangeError{op, "Price", "<", "0", 0, study.Price}
+	}
+	if study.Rate <= 0 {
+		return nil, &RangeError{op, "Rate", "<=", "0", 0, study.Rate}
+	}
+	if study.Years < 1 {
+		return nil, &RangeError{op, "Years", "<", "1", 1, float64(study.Years)}
+	}
+	pw := PresentWorthFactor(study.Rate, study.Years)
+	results := make([]EconomicResult, len(candidates))
+	for i, cand := range candidates {
+		field := "candidate " + strconv.Itoa(i)
+		if cand.Nsc < 1 {
+			return nil, &RangeError{op, field + " Nsc", "<", "1", 1, float64(cand.Nsc)}
+		}
+		if cand.Cost < 0 {
+			return nil, &RangeError{op, field + " Cost", "<", "0", 0, cand.Cost}
+		}
+		cc, err := NewCurrentCalc(cand.Conductor)
+		if err != nil {
+			return nil, &OpError{op + " " + field, err}
+		}
+		if cand.Conditions != nil {
+			if err := cc.SetConditions(*cand.Conditions); err != nil {
+				return nil, &OpError{op + " " + field, err}
+			}
+		}
+		oi, err := NewOperatingItem(cc, study.TempMaxOp, cand.Nsc)
+		if err != nil {
+			return nil, &OpError{op + " " + field, err}
+		}
+		results[i] = cand.evaluate(op, oi, study, pw)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Total < results[j].Total })
+	return results, nil
+}
+
+// evaluate Returns the evaluation of candidate ec with OperatingItem oi and present worth
+// factor pw
+func (ec EconomicCandidate) evaluate(op string, oi *OperatingItem, study EconomicStudy,
+	pw float64) EconomicResult {
+	er := EconomicResult{Candidate: ec, Total: math.Inf(1)}
+	ic, err := oi.Current(study.Ta)
+	if err != nil {
+		er.Err = &OpError{op, err}
+		return er
+	}
+	er.Rating = ic * float64(ec.Nsc)
+	if er.Rating < study.Current {
+		er.Err = &RangeError{op, "Rating", "<", "Current", study.Current, er.Rating}
+		return er
+	}
+	lr, err := oi.Losses(phaseProfile(study.Profile, ec.Nsc))
+	if err != nil {
+		er.Err = &OpError{op, err}
+		return er
+	}
+	for k, ls := range lr.Steps {
+		if ls.Tc > study.TempMaxOp {
+			er.Err = &RangeError{op, "step " + strconv.Itoa(k) + " Tc", ">", "TempMaxOp",
+				study.TempMaxOp, ls.Tc}
+			return er
+		}
+	}
+	er.Feasible = true
+	er.Losses = lr
+	er.AnnualEnergy = 3 * lr.Energy * 8760 / lr.Hours
+	er.LossCost = er.AnnualEnergy * study.Price * pw
+	er.Total = ec.Cost + er.LossCost
+	return er
+}
@@ -0,0 +1,135 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getEconomicCandidates() []EconomicCandidate {
+	makers := []ConductorMaker{
+		{"ACSR 266.8 MCM PARTRIDGE", CC_ACSR, 16.31, 157.2, 0.546, 5100, 0.2133, 0, ""},
+		{"ACSR 477 MCM HAWK", CC_ACSR, 21.79, 280.8, 0.975, 8820, 0.1195, 0, ""},
+		{"ACSR 795 MCM DRAKE", CC_ACSR, 28.14, 468.5, 1.628, 14290, 0.0718, 0, ""},
+		{"ACSR 1272 MCM BITTERN", CC_ACSR, 34.16, 726.8, 2.134, 15600, 0.0454, 0, ""},
+	}
+	costs := []float64{60000, 75000, 95000, 130000}
+	candidates := make([]EconomicCandidate, len(makers))
+	for i := range makers {
+		candidates[i] = EconomicCandidate{makers[i].Get(), 1, costs[i], nil}
+	}
+	return candidates
+}
+
+func getEconomicStudy() EconomicStudy {
+	profile := []LoadStep{{1000, 600, 30, nil}, {3760, 400, 25, nil}, {4000, 200, 15, nil}}
+	return EconomicStudy{600, 75, 30, profile, 0.08, 0.08, 30}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Economic_PresentWorth(t *testing.T) {
+	if pw := PresentWorthFactor(0.1, 1); math.Abs(pw-1/1.1) > 1e-12 {
+		t.Errorf("%f expected got %f", 1/1.1, pw)
+	}
+	if pw := PresentWorthFactor(0.08, 30); math.Abs(pw-11.2578) > 1e-4 {
+		t.Errorf("11.2578 expected got %f", pw)
+	}
+}
+
+func Test_Economic_Select(t *testing.T) {
+	study := getEconomicStudy()
+	results, err := SelectConductor(getEconomicCandidates(), study)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pw := PresentWorthFactor(study.Rate, study.Years)
+	for i, er := range results {
+		if i > 0 && er.Total < results[i-1].Total {
+			t.Error("Sorted by increasing Total expected")
+		}
+		if !er.Feasible {
+			if !math.IsInf(er.Total, 1) || er.Err == nil || er.Losses != nil {
+				t.Errorf("Unfeasible result expected got %+v", er)
+			}
+			continue
+		}
+		if er.Rating < study.Current {
+			t.Errorf("%s: rating %f below %f", er.Candidate.Conductor.Name(), er.Rating,
+				study.Current)
+		}
+		annual := 3 * er.Losses.Energy * 8760 / er.Losses.Hours
+		if math.Abs(er.Total-er.Candidate.Cost-annual*study.Price*pw) > 1e-6 {
+			t.Errorf("%s: wrong total %f", er.Candidate.Conductor.Name(), er.Total)
+		}
+	}
+	last := results[len(results)-1]
+	if last.Feasible || last.Candidate.Conductor.Name() != "ACSR 266.8 MCM PARTRIDGE" {
+		t.Errorf("PARTRIDGE unfeasible expected got %+v", last)
+	}
+	if !errors.Is(last.Err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", last.Err)
+	}
+}
+
+func Test_Economic_Bundle(t *testing.T) {
+	study := getEconomicStudy()
+	candidates := getEconomicCandidates()[:1]
+	candidates[0].Nsc = 2
+	results, _ := SelectConductor(candidates, study)
+	if !results[0].Feasible {
+		t.Errorf("Twin PARTRIDGE feasible expected got %v", results[0].Err)
+	}
+}
+
+func Test_Economic_Errors(t *testing.T) {
+	study := getEconomicStudy()
+	if _, err := SelectConductor(nil, study); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	candidates := getEconomicCandidates()
+	for _, s := range []EconomicStudy{
+		{0, 75, 30, study.Profile, 0.08, 0.08, 30},
+		{600, TC_MAX + 1, 30, study.Profile, 0.08, 0.08, 30},
+		{600, 75, 30, study.Profile, -1, 0.08, 30},
+		{600, 75, 30, study.Profile, 0.08, 0, 30},
+		{600, 75, 30, study.Profile, 0.08, 0.08, 0},
+	} {
+		if _, err := SelectConductor(candidates, s); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%+v: range error expected got %v", s, err)
+		}
+	}
+	if _, err := SelectConductor(candidates, EconomicStudy{600, 75, 30, nil, 0.08, 0.08,
+		30}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	candidates[1].Nsc = 0
+	if _, err := SelectConductor(candidates, study); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	candidates[1].Nsc = 1
+	candidates[1].Conductor = nil
+	if _, err := SelectConductor(candidates, study); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+}
+
+func Example_SelectConductor() {
+	results, _ := SelectConductor(getEconomicCandidates(), getEconomicStudy())
+	for _, er := range results {
+		if !er.Feasible {
+			fmt.Printf("%-24s %4.0f A  not feasible\n", er.Candidate.Conductor.Name(), er.Rating)
+			continue
+		}
+		fmt.Printf("%-24s %4.0f A %7.0f $/km\n", er.Candidate.Conductor.Name(), er.Rating,
+			er.Total)
+	}
+	// Output:
+	// ACSR 1272 MCM BITTERN    1125 A  275704 $/km
+	// ACSR 795 MCM DRAKE        850 A  328579 $/km
+	// ACSR 477 MCM HAWK         617 A  476591 $/km
+	// ACSR 266.8 MCM PARTRIDGE  428 A  not feasible
+}
@@ -1,13 +1,130 @@
-// Copyright Cristian Echeverría Rabí
-
-package conductor
-
-//----------------------------------------------------------------------------------------
-
-type ValueError struct {
-	msg string
-}
-
-func (e *ValueError) Error() string {
-	return e.msg
-}
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Sentinel errors. Every error returned by the library matches one of them with errors.Is.
+//
+//	ErrOutOfRange    : A parameter value is out of its valid range (*RangeError)
+//	ErrInvalidConfig : Invalid arguments or configuration, e.g. nil objects (*ConfigError)
+//	ErrConvergence   : An iterative calculation did not converge (*ConvergenceError)
+//	ErrNotFound      : Object not found in a Repository (*NotFoundError)
+var (
+	ErrOutOfRange    = errors.New("conductor: value out of range")
+	ErrInvalidConfig = errors.New("conductor: invalid configuration")
+	ErrConvergence   = errors.New("conductor: calculation did not converge")
+	ErrNotFound      = errors.New("conductor: not found")
+)
+
+//----------------------------------------------------------------------------------------
+
+// RangeError Parameter value out of its valid range. The message reads
+// "Op: Field Rel Limit", e.g. "CurrentCalc.Tc: ta < TA_MIN".
+type RangeError struct {
+	Op    string  // Operation that failed, e.g. "CurrentCalc.Tc"
+	Field string  // Parameter name, e.g. "ta"
+	Rel   string  // Relation between value and bound that caused the error: <, <=, >, >=
+	Limit string  // Name of the bound, e.g. "TA_MIN" or "0"
+	Bound float64 // Value of the bound
+	Value float64 // Value received
+}
+
+func (e *RangeError) Error() string {
+	return e.Op + ": " + e.Field + " " + e.Rel + " " + e.Limit
+}
+
+func (e *RangeError) Is(target error) bool {
+	return target == ErrOutOfRange
+}
+
+//----------------------------------------------------------------------------------------
+
+// ConfigError Invalid argument or configuration. The message reads "Op: Field Msg",
+// e.g. "NewCurrentCalc: Conductor == nil".
+type ConfigError struct {
+	Op    string // Operation that failed
+	Field string // Argument or attribute with the problem
+	Msg   string // Description of the problem
+}
+
+func (e *ConfigError) Error() string {
+	return e.Op + ": " + e.Field + " " + e.Msg
+}
+
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+//----------------------------------------------------------------------------------------
+
+// ConvergenceError Iterative calculation did not converge
+type ConvergenceError struct {
+	Op   string // Operation that failed
+	Iter int    // Iterations performed
+}
+
+func (e *ConvergenceError) Error() string {
+	return e.Op + ": ITER_MAX exceeded (" + strconv.Itoa(e.Iter) + " iterations)"
+}
+
+func (e *ConvergenceError) Is(target error) bool {
+	return target == ErrConvergence
+}
+
+//----------------------------------------------------------------------------------------
+
+// NotFoundError Object not found in a Repository
+type NotFoundError struct {
+	Op   string // Operation that failed
+	Kind string // Kind of object: "category", "conductor" or "table"
+	Id   string // Id of the object
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Op + ": " + e.Kind + " " + e.Id + " not found"
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+//----------------------------------------------------------------------------------------
+
+// OpError Error of an inner operation reported by operation Op. Use errors.As to get the
+// inner typed error.
+type OpError struct {
+	Op  string // Operation that failed
+	Err error  // Inner error
+}
+
+func (e *OpError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+//----------------------------------------------------------------------------------------
+
+// ValueError Error with message only.
+//
+// Deprecated: the library returns *RangeError, *ConfigError, *ConvergenceError,
+// *NotFoundError and *OpError. ValueError matches ErrInvalidConfig.
+type ValueError struct {
+	msg string
+}
+
+func (e *ValueError) Error() string {
+	return e.msg
+}
+
+func (e *ValueError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
@@ -0,0 +1,111 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_Errors_RangeError(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+
+	_, err := cc.Tc(TA_MIN-1, 100)
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ErrOutOfRange expected got %v", err)
+	}
+	var rerr *RangeError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("*RangeError expected got %T", err)
+	}
+	if rerr.Op != "CurrentCalc.Tc" || rerr.Field != "ta" || rerr.Limit != "TA_MIN" {
+		t.Errorf("Wrong RangeError %+v", rerr)
+	}
+	if rerr.Bound != TA_MIN || rerr.Value != TA_MIN-1 {
+		t.Errorf("Wrong RangeError %+v", rerr)
+	}
+
+	icmax, _ := cc.Current(25, TC_MAX)
+	_, err = cc.Tc(25, icmax+1)
+	if !errors.As(err, &rerr) || rerr.Bound != icmax || rerr.Value != icmax+1 {
+		t.Errorf("RangeError with Bound = Imax expected got %v", err)
+	}
+
+	if err := cc.SetSunEffect(2); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ErrOutOfRange expected got %v", err)
+	}
+	_, err = NewOperatingItem(cc, TC_MAX+1, 1)
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ErrOutOfRange expected got %v", err)
+	}
+}
+
+func Test_Errors_Wrapped(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+
+	_, err := cc.WithAltitude(-1)
+	var rerr *RangeError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("*RangeError expected got %T", err)
+	}
+	if rerr.Op != "CurrentCalc.SetAltitude" || rerr.Value != -1 {
+		t.Errorf("Wrong RangeError %+v", rerr)
+	}
+	var oerr *OpError
+	if !errors.As(err, &oerr) || oerr.Op != "CurrentCalc.WithAltitude" {
+		t.Errorf("*OpError expected got %v", err)
+	}
+
+	_, err = cc.Sensitivity(TA_MAX+1, 100, nil)
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ErrOutOfRange expected got %v", err)
+	}
+}
+
+func Test_Errors_Others(t *testing.T) {
+	_, err := NewCurrentCalc(nil)
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("ErrInvalidConfig expected got %v", err)
+	}
+	_, err = NewOperatingTable(nil, "")
+	var cerr *ConfigError
+	if !errors.As(err, &cerr) || cerr.Field != "items" {
+		t.Errorf("*ConfigError expected got %v", err)
+	}
+
+	cc, _ := NewCurrentCalc(getConductor())
+	cc.SetIterMax(1)
+	_, err = cc.Tc(25, 500)
+	if !errors.Is(err, ErrConvergence) {
+		t.Errorf("ErrConvergence expected got %v", err)
+	}
+
+	r := NewMemoryRepository()
+	_, err = r.GetConductor("X")
+	var nerr *NotFoundError
+	if !errors.As(err, &nerr) || nerr.Kind != "conductor" || nerr.Id != "X" {
+		t.Errorf("*NotFoundError expected got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("ErrNotFound expected got %v", err)
+	}
+
+	_, err = Convert(1, UN_MM, UN_KGF)
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("ErrInvalidConfig expected got %v", err)
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Example_Errors() {
+	cc, _ := NewCurrentCalc(getConductor())
+	_, err := cc.Current(25, TC_MAX+10)
+	var rerr *RangeError
+	if errors.As(err, &rerr) {
+		fmt.Printf("%s: %s=%.0f %s %.0f", err, rerr.Field, rerr.Value, rerr.Rel, rerr.Bound)
+	}
+	// Output:
+	// CurrentCalc.Current: tc > TC_MAX: tc=2010 > 2000
+}
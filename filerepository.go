@@ -0,0 +1,366 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Sub directories of a FileRepository
+const (
+	categoriesDir = "categories"
+	conductorsDir = "conductors"
+	tablesDir     = "tables"
+)
+
+//----------------------------------------------------------------------------------------
+
+// JSON documents stored by FileRepository (see also CategoryDoc and ConductorDoc).
+// References are stored by Id.
+
+type operatingItemDoc struct {
+	Conductor   string  `json:"conductor"`
+	TempMaxOp   float64 `json:"tempMaxOp"`
+	Nsc         int     `json:"nsc"`
+	Altitude    float64 `json:"altitude"`
+	AirVelocity float64 `json:"airVelocity"`
+	WindAngle   float64 `json:"windAngle"`
+	SunEffect   float64 `json:"sunEffect"`
+	Emissivity  float64 `json:"emissivity"`
+	Formula     string  `json:"formula"`
+	DeltaTemp   float64 `json:"deltaTemp"`
+	IterMax     int     `json:"iterMax"`
+}
+
+type operatingTableDoc struct {
+	Id    string             `json:"id"`
+	Items []operatingItemDoc `json:"items"`
+}
+
+//----------------------------------------------------------------------------------------
+
+// OpenFileRepository Returns *FileRepository stored in directory dir. The directory and
+// its sub directories are created if they do not exist and existing JSON files are loaded.
+// Custom heat balance models used by stored tables must be registered before.
+// dir string : Repository directory
+func OpenFileRepository(dir string) (*FileRepository, error) {
+	for _, sub := range []string{categoriesDir, conductorsDir, tablesDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, err
+		}
+	}
+	fr := &FileRepository{dir: dir, mem: NewMemoryRepository()}
+	if err := fr.load(); err != nil {
+		return nil, err
+	}
+	return fr, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// FileRepository Repository that stores one JSON file per object in a directory tree:
+// dir/categories, dir/conductors and dir/tables. Objects are cached in memory, so reads
+// do not touch the disk. Safe for concurrent use within a process.
+type FileRepository struct {
+	mu  sync.Mutex        // Serializes writes
+	dir string            // Repository directory
+	mem *MemoryRepository // In memory cache
+}
+
+func (fr *FileRepository) Dir() string {
+	return fr.dir
+}
+
+func (fr *FileRepository) GetCategory(id string) (*Category, error) {
+	return fr.mem.GetCategory(id)
+}
+
+func (fr *FileRepository) ListCategories() ([]*Category, error) {
+	return fr.mem.ListCategories()
+}
+
+// PutCategory Stores cat (see MemoryRepository.PutCategory). Conductors loaded when the
+// repository is opened again use the new category.
+func (fr *FileRepository) PutCategory(cat *Category) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	var old *Category
+	if cat != nil {
+		old, _ = fr.mem.GetCategory(cat.id)
+	}
+	if err := fr.mem.PutCategory(cat); err != nil {
+		return err
+	}
+	if err := fr.write(categoriesDir, cat.id, NewCategoryDoc(cat)); err != nil {
+		fr.mem.mu.Lock()
+		if old != nil {
+			fr.mem.categories[cat.id] = old
+		} else {
+			delete(fr.mem.categories, cat.id)
+		}
+		fr.mem.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (fr *FileRepository) DeleteCategory(id string) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	if err := fr.mem.DeleteCategory(id); err != nil {
+		return err
+	}
+	return fr.remove(categoriesDir, id)
+}
+
+func (fr *FileRepository) GetConductor(id string) (*Conductor, error) {
+	return fr.mem.GetConductor(id)
+}
+
+func (fr *FileRepository) ListConductors() ([]*Conductor, error) {
+	return fr.mem.ListConductors()
+}
+
+func (fr *FileRepository) PutConductor(c *Conductor) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	var old *Conductor
+	if c != nil {
+		old, _ = fr.mem.GetConductor(c.id)
+	}
+	if err := fr.mem.PutConductor(c); err != nil {
+		return err
+	}
+	if err := fr.write(conductorsDir, c.id, NewConductorDoc(c)); err != nil {
+		fr.mem.mu.Lock()
+		if old != nil {
+			fr.mem.conductors[c.id] = old
+		} else {
+			delete(fr.mem.conductors, c.id)
+		}
+		fr.mem.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (fr *FileRepository) DeleteConductor(id string) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	if err := fr.mem.DeleteConductor(id); err != nil {
+		return err
+	}
+	return fr.remove(conductorsDir, id)
+}
+
+func (fr *FileRepository) GetOperatingTable(id string) (*OperatingTable, error) {
+	return fr.mem.GetOperatingTable(id)
+}
+
+func (fr *FileRepository) ListOperatingTables() ([]*OperatingTable, error) {
+	return fr.mem.ListOperatingTables()
+}
+
+// PutOperatingTable Stores ot. Heat balance models of the items are stored by name, so
+// they must be registered (see RegisterModel).
+func (fr *FileRepository) PutOperatingTable(ot *OperatingTable) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	var old *OperatingTable
+	if ot != nil {
+		old, _ = fr.mem.GetOperatingTable(ot.id)
+		for i, item := range ot.items {
+			cc := item.currentCalc
+			if m, err := GetModel(cc.formula); err != nil || m != cc.model {
+				return &ConfigError{"FileRepository.PutOperatingTable",
+					"item " + strconv.Itoa(i) + " model " + cc.formula, "not registered"}
+			}
+		}
+	}
+	if err := fr.mem.PutOperatingTable(ot); err != nil {
+		return err
+	}
+	doc := operatingTableDoc{Id: ot.id, Items: make([]operatingItemDoc, len(ot.items))}
+	for i, item := range ot.items {
+		cc := item.currentCalc
+		doc.Items[i] = operatingItemDoc{cc.conductor.id, item.tempMaxOp, item.nsc, cc.altitude,
+			cc.airVelocity, cc.windAngle, cc.sunEffect, cc.emissivity, cc.formula, cc.deltaTemp,
+			cc.iterMax}
+	}
+	if err := fr.write(tablesDir, ot.id, &doc); err != nil {
+		fr.mem.mu.Lock()
+		if old != nil {
+			fr.mem.tables[ot.id] = old
+		} else {
+			delete(fr.mem.tables, ot.id)
+		}
+		fr.mem.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (fr *FileRepository) DeleteOperatingTable(id string) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	if err := fr.mem.DeleteOperatingTable(id); err != nil {
+		return err
+	}
+	return fr.remove(tablesDir, id)
+}
+
+//----------------------------------------------------------------------------------------
+
+// load Reads all JSON files into the memory cache resolving references by Id
+func (fr *FileRepository) load() error {
+	var catDocs []CategoryDoc
+	if err := fr.readAll(categoriesDir, func() interface{} {
+		catDocs = append(catDocs, CategoryDoc{})
+		return &catDocs[len(catDocs)-1]
+	}); err != nil {
+		return err
+	}
+	for _, d := range catDocs {
+		if err := fr.mem.PutCategory(d.Category()); err != nil {
+			return err
+		}
+	}
+
+	var condDocs []ConductorDoc
+	if err := fr.readAll(conductorsDir, func() interface{} {
+		condDocs = append(condDocs, ConductorDoc{})
+		return &condDocs[len(condDocs)-1]
+	}); err != nil {
+		return err
+	}
+	for _, d := range condDocs {
+		c, err := d.Conductor(fr.mem.GetCategory)
+		if err != nil {
+			return &OpError{"OpenFileRepository: conductor " + d.Id, err}
+		}
+		if err := fr.mem.PutConductor(c); err != nil {
+			return err
+		}
+	}
+
+	var tableDocs []operatingTableDoc
+	if err := fr.readAll(tablesDir, func() interface{} {
+		tableDocs = append(tableDocs, operatingTableDoc{})
+		return &tableDocs[len(tableDocs)-1]
+	}); err != nil {
+		return err
+	}
+	for _, d := range tableDocs {
+		items := make([]*OperatingItem, len(d.Items))
+		for i, di := range d.Items {
+			item, err := fr.operatingItem(&di)
+			if err != nil {
+				return &OpError{"OpenFileRepository: table " + d.Id, err}
+			}
+			items[i] = item
+		}
+		ot, err := NewOperatingTable(items, d.Id)
+		if err != nil {
+			return &OpError{"OpenFileRepository: table " + d.Id, err}
+		}
+		if err := fr.mem.PutOperatingTable(ot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operatingItem Returns *OperatingItem from its JSON document
+func (fr *FileRepository) operatingItem(d *operatingItemDoc) (*OperatingItem, error) {
+	c, err := fr.mem.GetConductor(d.Conductor)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := NewCurrentCalc(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := cc.SetAltitude(d.Altitude); err != nil {
+		return nil, err
+	}
+	if err := cc.SetAirVelocity(d.AirVelocity); err != nil {
+		return nil, err
+	}
+	if err := cc.SetWindAngle(d.WindAngle); err != nil {
+		return nil, err
+	}
+	if err := cc.SetSunEffect(d.SunEffect); err != nil {
+		return nil, err
+	}
+	if err := cc.SetEmissivity(d.Emissivity); err != nil {
+		return nil, err
+	}
+	if err := cc.SetFormula(d.Formula); err != nil {
+		return nil, err
+	}
+	if err := cc.SetDeltaTemp(d.DeltaTemp); err != nil {
+		return nil, err
+	}
+	if err := cc.SetIterMax(d.IterMax); err != nil {
+		return nil, err
+	}
+	return NewOperatingItem(cc, d.TempMaxOp, d.Nsc)
+}
+
+// readAll Decodes every *.json file of sub directory into values returned by next
+func (fr *FileRepository) readAll(sub string, next func() interface{}) error {
+	paths, err := filepath.Glob(filepath.Join(fr.dir, sub, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, next()); err != nil {
+			return &ConfigError{"OpenFileRepository", p, err.Error()}
+		}
+	}
+	return nil
+}
+
+// write Stores v as JSON in sub directory replacing the file atomically
+func (fr *FileRepository) write(sub string, id string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := fr.path(sub, id)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// remove Deletes the file of id from sub directory
+func (fr *FileRepository) remove(sub string, id string) error {
+	err := os.Remove(fr.path(sub, id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// path Returns file path for id. Id is escaped so it can not leave the sub directory.
+func (fr *FileRepository) path(sub string, id string) string {
+	name := strings.Replace(url.PathEscape(id), ".", "%2E", -1)
+	return filepath.Join(fr.dir, sub, name+".json")
+}
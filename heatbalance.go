@@ -0,0 +1,100 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Convection regime used by CurrentCalc for convection heat loss
+// CV_NATURAL     = "NATURAL"        Natural convection (no wind or natural term dominates)
+// CV_FORCED_LOW  = "FORCED_LOW"     Forced convection, low Reynolds number equation
+// CV_FORCED_HIGH = "FORCED_HIGH"    Forced convection, high Reynolds number equation
+const (
+	CV_NATURAL     = "NATURAL"
+	CV_FORCED_LOW  = "FORCED_LOW"
+	CV_FORCED_HIGH = "FORCED_HIGH"
+)
+
+//----------------------------------------------------------------------------------------
+
+// HeatBalance Intermediate values of the heat balance of a conductor for ambient
+// temperature Ta and conductor temperature Tc. Heat terms are per unit length [Watt/ft].
+// Current = sqrt(Qj/Rc) with Qj = Qc + Qr - Qs (Current = 0 if Qj < 0 or Ta >= Tc).
+// Air properties and convection details (Pb to Convection) are set by the CLASSIC and
+// IEEE models; other models may leave them in zero.
+type HeatBalance struct {
+	Model      string  // Name of the HeatBalanceModel
+	Ta         float64 // Ambient temperature [°C]
+	Tc         float64 // Conductor temperature [°C]
+	D          float64 // Conductor diameter [in]
+	Pb         float64 // Barometric pressure [cmHg]
+	V          float64 // Velocity of air stream [ft/hour]
+	Tm         float64 // Film temperature (Tc + Ta)/2 [°C]
+	Rf         float64 // Air relative density [lb/ft^3]
+	Uf         float64 // Air absolute viscosity [lb/(ft*hour)]
+	Kf         float64 // Air thermal conductivity [Watt/(ft*°C)]
+	Reynolds   float64 // Reynolds number D*Rf*V/Uf
+	Kangle     float64 // Wind direction factor applied to forced convection
+	Qcn        float64 // Natural convection heat loss
+	Qc1        float64 // Forced convection heat loss, high Reynolds equation
+	Qc2        float64 // Forced convection heat loss, low Reynolds equation
+	Qc         float64 // Convection heat loss used in the balance
+	Convection string  // Convection regime used: CV_NATURAL, CV_FORCED_LOW or CV_FORCED_HIGH
+	Qr         float64 // Radiation heat loss
+	Qs         float64 // Solar heat gain
+	Rc         float64 // Resistance at Tc [Ohm/ft]
+	Qj         float64 // Joule heating needed for the balance (Qc + Qr - Qs, >= 0)
+	Current    float64 // Current [A]
+}
+
+//----------------------------------------------------------------------------------------
+
+// HeatBalance Returns *HeatBalance with the intermediate values of Current(ta, tc)
+func (cc *CurrentCalc) HeatBalance(ta float64, tc float64) (*HeatBalance, error) {
+	if ta < TA_MIN {
+		return nil, &RangeError{"CurrentCalc.HeatBalance", "ta", "<", "TA_MIN", TA_MIN, ta}
+	}
+	if ta > TA_MAX {
+		return nil, &RangeError{"CurrentCalc.HeatBalance", "ta", ">", "TA_MAX", TA_MAX, ta}
+	}
+	if tc < TC_MIN {
+		return nil, &RangeError{"CurrentCalc.HeatBalance", "tc", "<", "TC_MIN", TC_MIN, tc}
+	}
+	if tc > TC_MAX {
+		return nil, &RangeError{"CurrentCalc.HeatBalance", "tc", ">", "TC_MAX", TC_MAX, tc}
+	}
+	hb := &HeatBalance{}
+	cc.heatBalance(ta, tc, hb)
+	return hb, nil
+}
+
+// heatBalance Fills hb for ta and tc with the model of cc. Values must be already verified.
+func (cc *CurrentCalc) heatBalance(ta float64, tc float64, hb *HeatBalance) {
+	*hb = HeatBalance{Ta: ta, Tc: tc, Model: cc.formula}
+	hb.D = cc.conductor.diameter / 25.4 // Diámetro en pulgadas
+	cc.model.Joule(cc, hb)
+	cc.model.Convection(cc, hb)
+	cc.model.Radiation(cc, hb)
+	cc.model.Solar(cc, hb)
+
+	if tc <= ta || (hb.Qc+hb.Qr) < hb.Qs {
+		hb.Qj = 0
+		hb.Current = 0
+	} else {
+		hb.Qj = hb.Qc + hb.Qr - hb.Qs
+		hb.Current = math.Sqrt(hb.Qj / hb.Rc)
+	}
+}
+
+// windAngleFactor Returns IEEE 738 wind direction factor for angle a [°] between air stream
+// and conductor axis. Returns exactly 1 for a perpendicular air stream (a = 90).
+func windAngleFactor(a float64) float64 {
+	if a == 90 {
+		return 1
+	}
+	phi := a * math.Pi / 180
+	return 1.194 - math.Cos(phi) + 0.194*math.Cos(2*phi) + 0.368*math.Sin(2*phi)
+}
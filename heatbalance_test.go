@@ -0,0 +1,90 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func Test_CurrentCalc_HeatBalance(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+
+	for _, f := range []string{CF_IEEE, CF_CLASSIC} {
+		cc.SetFormula(f)
+		for _, x := range [][2]float64{{25, 50}, {30, 60}, {-10, 100}, {40, 45}} {
+			hb, err := cc.HeatBalance(x[0], x[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			cur, _ := cc.Current(x[0], x[1])
+			if hb.Current != cur {
+				t.Errorf("%s Current %f expected got %f", f, cur, hb.Current)
+			}
+			if math.Abs(hb.Qj-hb.Current*hb.Current*hb.Rc) > 1e-9 {
+				t.Error("Qj = Rc*I^2 expected")
+			}
+			if hb.Current > 0 && math.Abs(hb.Qc+hb.Qr-hb.Qs-hb.Qj) > 1e-9 {
+				t.Error("Qj = Qc + Qr - Qs expected")
+			}
+		}
+	}
+
+	_, err := cc.HeatBalance(TA_MIN-0.001, 50)
+	if err == nil {
+		t.Error("ta < TA_MIN error expected")
+	}
+	_, err = cc.HeatBalance(25, TC_MAX+0.001)
+	if err == nil {
+		t.Error("tc > TC_MAX error expected")
+	}
+}
+
+func Test_CurrentCalc_HeatBalanceConvection(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+
+	cc.SetAirVelocity(0)
+	hb, _ := cc.HeatBalance(25, 50)
+	if hb.Convection != CV_NATURAL || hb.Qc != hb.Qcn {
+		t.Errorf("CV_NATURAL expected got %s", hb.Convection)
+	}
+	if hb.Qc1 != 0 || hb.Qc2 != 0 {
+		t.Error("Forced convection = 0 expected")
+	}
+
+	cc.SetAirVelocity(2)
+	hb, _ = cc.HeatBalance(25, 50)
+	if hb.Convection != CV_FORCED_LOW || hb.Qc != hb.Qc2 {
+		t.Errorf("CV_FORCED_LOW expected got %s", hb.Convection)
+	}
+	if hb.Qc < hb.Qc1 || hb.Qc < hb.Qcn {
+		t.Error("IEEE uses the largest convection term")
+	}
+
+	cc.SetFormula(CF_CLASSIC)
+	cc.SetAirVelocity(60)
+	hb, _ = cc.HeatBalance(25, 50)
+	if hb.Reynolds < 12000 {
+		t.Fatalf("Reynolds >= 12000 expected got %f", hb.Reynolds)
+	}
+	if hb.Convection != CV_FORCED_HIGH || hb.Qc != hb.Qc1 {
+		t.Errorf("CV_FORCED_HIGH expected got %s", hb.Convection)
+	}
+
+	hb, _ = cc.HeatBalance(50, 25)
+	if hb.Current != 0 || hb.Qc != 0 {
+		t.Error("Current = 0 and Qc = 0 expected with ta > tc")
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Example_CurrentCalc_HeatBalance() {
+	cc, _ := NewCurrentCalc(getConductor())
+	hb, _ := cc.HeatBalance(25, 50)
+	fmt.Printf("%s Re=%.0f Qc=%.2f Qr=%.2f Qs=%.2f I=%.2f", hb.Convection, hb.Reynolds, hb.Qc,
+		hb.Qr, hb.Qs, hb.Current)
+	// Output:
+	// FORCED_LOW Re=10652 Qc=9.70 Qr=2.05 Qs=3.83 I=517.68
+}
@@ -0,0 +1,159 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+//----------------------------------------------------------------------------------------
+
+// HeatBalanceModel Physics of the steady state heat balance used by CurrentCalc. Each
+// method receives the *CurrentCalc (conductor and conditions, read only) and a
+// *HeatBalance with Ta, Tc and D set, and fills the terms it is responsible for. Heat
+// terms are per unit length [Watt/ft]. CurrentCalc calls Joule, Convection, Radiation and
+// Solar in that order and then solves Qc + Qr - Qs = Rc*I². Models must be safe for
+// concurrent use.
+type HeatBalanceModel interface {
+	Name() string                                // Name used to register the model
+	Joule(cc *CurrentCalc, hb *HeatBalance)      // Sets Rc, resistance at Tc [Ohm/ft]
+	Convection(cc *CurrentCalc, hb *HeatBalance) // Sets Qc (and optionally Qcn, Qc1, ...)
+	Radiation(cc *CurrentCalc, hb *HeatBalance)  // Sets Qr
+	Solar(cc *CurrentCalc, hb *HeatBalance)      // Sets Qs
+}
+
+//----------------------------------------------------------------------------------------
+
+// Standard models, never replaced in the registry
+var (
+	classicModel HeatBalanceModel = &standardModel{CF_CLASSIC}
+	ieeeModel    HeatBalanceModel = &standardModel{CF_IEEE}
+)
+
+// Registered models by name
+var (
+	modelsMu sync.RWMutex
+	models   = map[string]HeatBalanceModel{
+		CF_CLASSIC: classicModel,
+		CF_IEEE:    ieeeModel,
+	}
+)
+
+// RegisterModel Adds model m to the registry. Registered models can be selected by name
+// with CurrentCalc.SetFormula and GetModel. Names must be unique.
+func RegisterModel(m HeatBalanceModel) error {
+	if m == nil {
+		return &ConfigError{"RegisterModel", "model", "== nil"}
+	}
+	name := m.Name()
+	if name == "" {
+		return &ConfigError{"RegisterModel", "model name", "is empty"}
+	}
+	modelsMu.Lock()
+	defer modelsMu.Unlock()
+	if _, ok := models[name]; ok {
+		return &ConfigError{"RegisterModel", "model " + name, "already registered"}
+	}
+	models[name] = m
+	return nil
+}
+
+// GetModel Returns the registered model with name
+func GetModel(name string) (HeatBalanceModel, error) {
+	modelsMu.RLock()
+	defer modelsMu.RUnlock()
+	m, ok := models[name]
+	if !ok {
+		return nil, &NotFoundError{"GetModel", "model", name}
+	}
+	return m, nil
+}
+
+// ModelNames Returns the names of registered models sorted
+func ModelNames() []string {
+	modelsMu.RLock()
+	defer modelsMu.RUnlock()
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//----------------------------------------------------------------------------------------
+
+// standardModel CLASSIC and IEEE models. Both share the equations and differ in the
+// criteria to choose the forced convection equation.
+type standardModel struct {
+	name string // CF_CLASSIC or CF_IEEE
+}
+
+func (m *standardModel) Name() string {
+	return m.name
+}
+
+func (m *standardModel) Joule(cc *CurrentCalc, hb *HeatBalance) {
+	res := cc.conductor.r25 * (1 + cc.conductor.category.alpha*(hb.Tc-25.0))
+	hb.Rc = res * 0.0003048 // Resistencia en ohm/pies
+}
+
+func (m *standardModel) Convection(cc *CurrentCalc, hb *HeatBalance) {
+	ta, tc := hb.Ta, hb.Tc
+	hb.Pb = math.Pow(10, (1.880813592 - cc.altitude/18336.0)) // Presión barométrica en cmHg
+	hb.V = cc.airVelocity * 3600                              // Vel. viento en pies/hora
+	hb.Tm = 0.5 * (tc + ta)                                   // Temperatura media
+	hb.Rf = 0.2901577 * hb.Pb / (273 + hb.Tm)                 // Densidad rel.aire [lb/ft^3]
+	hb.Uf = 0.04165 + 0.000111*hb.Tm                          // Viscosidad abs. aire [lb/(ft x hora)]
+	hb.Kf = 0.00739 + 0.0000227*hb.Tm                         // Coef. conductividad term. aire [Watt/(ft x °C)]
+	hb.Reynolds = hb.D * hb.Rf * hb.V / hb.Uf
+	hb.Kangle = windAngleFactor(cc.windAngle)
+	hb.Convection = CV_NATURAL
+
+	if tc <= ta {
+		return
+	}
+	hb.Qcn = 0.283 * math.Sqrt(hb.Rf) * math.Pow(hb.D, 0.75) * math.Pow(tc-ta, 1.25) // watt/ft
+	hb.Qc = hb.Qcn
+	if hb.V == 0 {
+		return
+	}
+	hb.Qc1 = 0.1695 * hb.Kf * (tc - ta) * math.Pow(hb.Reynolds, 0.6)
+	hb.Qc2 = hb.Kf * (tc - ta) * (1.01 + 0.371*math.Pow(hb.Reynolds, 0.52))
+	if hb.Kangle != 1 {
+		hb.Qc1 *= hb.Kangle
+		hb.Qc2 *= hb.Kangle
+	}
+	if m.name == CF_IEEE {
+		// IEEE criteria
+		if hb.Qc1 > hb.Qc {
+			hb.Qc = hb.Qc1
+			hb.Convection = CV_FORCED_HIGH
+		}
+		if hb.Qc2 > hb.Qc {
+			hb.Qc = hb.Qc2
+			hb.Convection = CV_FORCED_LOW
+		}
+	} else {
+		// CLASSIC criteria
+		if hb.Reynolds < 12000 {
+			hb.Qc = hb.Qc2
+			hb.Convection = CV_FORCED_LOW
+		} else {
+			hb.Qc = hb.Qc1
+			hb.Convection = CV_FORCED_HIGH
+		}
+	}
+}
+
+func (m *standardModel) Radiation(cc *CurrentCalc, hb *HeatBalance) {
+	LK := math.Pow((hb.Tc+273)/100, 4)
+	MK := math.Pow((hb.Ta+273)/100, 4)
+	hb.Qr = 0.138 * hb.D * cc.emissivity * (LK - MK)
+}
+
+func (m *standardModel) Solar(cc *CurrentCalc, hb *HeatBalance) {
+	hb.Qs = 3.87 * hb.D * cc.sunEffect
+}
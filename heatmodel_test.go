@@ -0,0 +1,136 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// shadeModel IEEE model without solar heating
+type shadeModel struct {
+	HeatBalanceModel
+}
+
+func (m *shadeModel) Name() string {
+	return "SHADE"
+}
+
+func (m *shadeModel) Solar(cc *CurrentCalc, hb *HeatBalance) {
+	hb.Qs = 0
+}
+
+// namedModel Model registered with another name
+type namedModel struct {
+	HeatBalanceModel
+	name string
+}
+
+func (m *namedModel) Name() string {
+	return m.name
+}
+
+func getShadeModel() HeatBalanceModel {
+	m, err := GetModel("SHADE")
+	if err == nil {
+		return m
+	}
+	ieee, _ := GetModel(CF_IEEE)
+	m = &shadeModel{ieee}
+	RegisterModel(m)
+	return m
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_HeatModel_Registry(t *testing.T) {
+	m := getShadeModel()
+	if x, err := GetModel("SHADE"); err != nil || x != m {
+		t.Errorf("SHADE expected got %v %v", x, err)
+	}
+	names := ModelNames()
+	if len(names) < 3 || names[0] != CF_CLASSIC || names[1] != CF_IEEE {
+		t.Errorf("Sorted names expected got %v", names)
+	}
+	if err := RegisterModel(m); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Duplicated name error expected got %v", err)
+	}
+	if err := RegisterModel(nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Nil model error expected got %v", err)
+	}
+	if _, err := GetModel("NONE"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Not found error expected got %v", err)
+	}
+}
+
+func Test_HeatModel_Concurrent(t *testing.T) {
+	// Registration while other goroutines select models (run with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			RegisterModel(&namedModel{&shadeModel{ieeeModel}, "SHADE " + strconv.Itoa(i)})
+		}(i)
+		go func() {
+			defer wg.Done()
+			cc, _ := NewCurrentCalc(getConductor())
+			if err := cc.SetFormula(CF_CLASSIC); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func Test_HeatModel_CurrentCalc(t *testing.T) {
+	m := getShadeModel()
+	cc, _ := NewCurrentCalc(getConductor())
+	if cc.Model().Name() != CF_IEEE {
+		t.Errorf("IEEE default expected got %s", cc.Model().Name())
+	}
+	shade, err := cc.WithModel(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shade.Formula() != "SHADE" || cc.Formula() != CF_IEEE {
+		t.Errorf("SHADE copy expected got %s %s", shade.Formula(), cc.Formula())
+	}
+	if _, err := cc.WithModel(nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Nil model error expected got %v", err)
+	}
+	cc.SetFormula("SHADE")
+	if cc.Model() != m {
+		t.Error("SetFormula must select registered models")
+	}
+	if err := cc.SetFormula("UNKNOWN"); !errors.Is(err, ErrNotFound) || cc.Model() != m {
+		t.Errorf("Not found error and model unchanged expected got %v", err)
+	}
+	if _, err := cc.WithFormula("UNKNOWN"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Not found error expected got %v", err)
+	}
+	cc.SetFormula(CF_IEEE)
+
+	// Same result as IEEE with sun effect 0
+	dark, _ := cc.WithSunEffect(0)
+	i1, _ := shade.Current(25, 50)
+	i2, _ := dark.Current(25, 50)
+	if i1 != i2 {
+		t.Errorf("%f expected got %f", i2, i1)
+	}
+	hb, _ := shade.HeatBalance(25, 50)
+	if hb.Model != "SHADE" || hb.Qs != 0 || hb.Qc == 0 {
+		t.Errorf("SHADE heat balance expected got %+v", hb)
+	}
+	tc, _ := shade.Tc(25, i1)
+	if math.Abs(tc-50) > 0.01 {
+		t.Errorf("Tc 50 expected got %f", tc)
+	}
+	ta, _ := shade.Ta(50, i1)
+	if math.Abs(ta-25) > 0.01 {
+		t.Errorf("Ta 25 expected got %f", ta)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+//----------------------------------------------------------------------------------------
+
+// CategoryDoc JSON document of a Category. Shared by FileRepository, the conductor command
+// and the rating service.
+type CategoryDoc struct {
+	Id      string  `json:"id"`
+	Name    string  `json:"name"`
+	Modelas float64 `json:"modelas"`
+	Coefexp float64 `json:"coefexp"`
+	Creep   float64 `json:"creep"`
+	Alpha   float64 `json:"alpha"`
+}
+
+// NewCategoryDoc Returns the document of cat
+func NewCategoryDoc(cat *Category) *CategoryDoc {
+	return &CategoryDoc{cat.id, cat.name, cat.modelas, cat.coefexp, cat.creep, cat.alpha}
+}
+
+// Category Returns *Category of the document
+func (d *CategoryDoc) Category() *Category {
+	return NewCategory(d.Name, d.Modelas, d.Coefexp, d.Creep, d.Alpha, d.Id)
+}
+
+//----------------------------------------------------------------------------------------
+
+// ConductorDoc JSON document of a Conductor. Category is the id of a category (a JSON
+// string) or a CategoryDoc object.
+type ConductorDoc struct {
+	Id       string          `json:"id"`
+	Name     string          `json:"name"`
+	Category json.RawMessage `json:"category"`
+	Diameter float64         `json:"diameter"`
+	Area     float64         `json:"area"`
+	Weight   float64         `json:"weight"`
+	Strength float64         `json:"strength"`
+	R25      float64         `json:"r25"`
+	Hcap     float64         `json:"hcap"`
+}
+
+// NewConductorDoc Returns the document of c referencing its category by id
+func NewConductorDoc(c *Conductor) *ConductorDoc {
+	id, _ := json.Marshal(c.category.id)
+	return &ConductorDoc{c.id, c.name, id, c.diameter, c.area, c.weight, c.strength, c.r25,
+		c.hcap}
+}
+
+// Conductor Returns *Conductor of the document. A category id is resolved with function
+// category, an object is decoded as CategoryDoc rejecting unknown fields.
+func (d *ConductorDoc) Conductor(category func(id string) (*Category, error)) (*Conductor,
+	error) {
+	op := "ConductorDoc.Conductor"
+	var cat *Category
+	var id string
+	switch {
+	case len(d.Category) == 0 || string(d.Category) == "null":
+		return nil, &ConfigError{op, "category", "is missing"}
+	case json.Unmarshal(d.Category, &id) == nil:
+		if category == nil {
+			return nil, &NotFoundError{op, "category", id}
+		}
+		var err error
+		if cat, err = category(id); err != nil {
+			return nil, err
+		}
+	default:
+		var cd CategoryDoc
+		dec := json.NewDecoder(bytes.NewReader(d.Category))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cd); err != nil {
+			return nil, &ConfigError{op, "category", "is not an id or an object: " + err.Error()}
+		}
+		cat = cd.Category()
+	}
+	return NewConductor(d.Name, cat, d.Diameter, d.Area, d.Weight, d.Strength, d.R25, d.Hcap,
+		d.Id), nil
+}
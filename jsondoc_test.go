@@ -0,0 +1,65 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func Test_JSONDoc_RoundTrip(t *testing.T) {
+	c := getRepositoryConductor()
+	data, err := json.Marshal(NewConductorDoc(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d ConductorDoc
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+	if string(d.Category) != `"AAAC"` {
+		t.Errorf("Category id expected got %s", d.Category)
+	}
+	x, err := d.Conductor(func(id string) (*Category, error) {
+		if id != "AAAC" {
+			return nil, &NotFoundError{"test", "category", id}
+		}
+		return CC_AAAC, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *x != *c {
+		t.Errorf("%+v expected got %+v", c, x)
+	}
+	cat := NewCategoryDoc(CC_ACSR).Category()
+	if *cat != *CC_ACSR {
+		t.Errorf("%+v expected got %+v", CC_ACSR, cat)
+	}
+}
+
+func Test_JSONDoc_InlineCategory(t *testing.T) {
+	data := `{"name": "X", "category": {"id": "C", "modelas": 6200, "coefexp": 1.6e-6,
+		"alpha": 0.004}, "diameter": 28.14, "area": 549, "weight": 1.562, "r25": 0.0547}`
+	var d ConductorDoc
+	json.Unmarshal([]byte(data), &d)
+	c, err := d.Conductor(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Category().Id() != "C" || c.Category().Alpha() != 0.004 || c.Area() != 549 {
+		t.Errorf("Inline category expected got %+v", c.Category())
+	}
+
+	for _, cat := range []string{`null`, `1`, `{"alpha": 0.004, "beta": 1}`} {
+		d.Category = json.RawMessage(cat)
+		if _, err := d.Conductor(nil); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: config error expected got %v", cat, err)
+		}
+	}
+	d.Category = json.RawMessage(`"AAAC"`)
+	if _, err := d.Conductor(nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Not found error expected got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// WeatherZone Weather conditions shared by the sections of a Line that cross the same
+// region. Sections keep a pointer to the zone, so updating a zone (e.g. from a weather
+// feed) changes the next Line.Rating of every section in it. A WeatherZone is not safe for
+// concurrent use: callers must not update a zone while a Line that uses it is rated.
+type WeatherZone struct {
+	Name        string  // Name of the zone
+	Ta          float64 // Ambient temperature [°C]
+	AirVelocity float64 // Velocity of air stream [ft/seg]
+	WindAzimuth float64 // Direction of air stream [° from north]
+	SunEffect   float64 // Sun effect factor (0 to 1)
+	Emissivity  float64 // Emissivity (0 to 1)
+}
+
+// LineSection Series segment of a Line with a single conductor, geometry and weather zone.
+// Span is the ruling span of the tension section, used by Line.SagTensionCalc.
+type LineSection struct {
+	Name      string       // Name of the section, e.g. "Str 12 - Str 40"
+	Conductor *Conductor   // *Conductor instance
+	Nsc       int          // Number of subconductors per phase
+	TempMaxOp float64      // Maximum operating temperature of the conductor [°C]
+	Length    float64      // Length of the section [m]
+	Span      float64      // Ruling span [m] (required > 0)
+	Azimuth   float64      // Orientation of the conductor axis [° from north]
+	Altitude  float64      // Mean altitude of the section [m]
+	Zone      *WeatherZone // Weather zone of the section
+}
+
+// windAngle Returns the angle between air stream of zone and the conductor axis [°]
+func (ls *LineSection) windAngle() float64 {
+	a := math.Mod(math.Abs(ls.Zone.WindAzimuth-ls.Azimuth), 180)
+	if a > 90 {
+		a = 180 - a
+	}
+	return a
+}
+
+//----------------------------------------------------------------------------------------
+
+// NewLine Returns *Line object
+// sections []*LineSection : Ordered sections of the line (at least one)
+// id       string         : Database id
+func NewLine(sections []*LineSection, id string) (*Line, error) {
+	if len(sections) < 1 {
+		return nil, &ConfigError{"NewLine", "len(sections)", "< 1"}
+	}
+	calcs := make([]*CurrentCalc, len(sections))
+	for i, s := range sections {
+		if s == nil {
+			return nil, &ConfigError{"NewLine", "section", "== nil"}
+		}
+		if s.Zone == nil {
+			return nil, &ConfigError{"NewLine", "section " + s.Name + " Zone", "== nil"}
+		}
+		if s.Nsc < 1 {
+			return nil, &RangeError{"NewLine", "section " + s.Name + " Nsc", "<", "1", 1,
+				float64(s.Nsc)}
+		}
+		if s.TempMaxOp < TC_MIN {
+			return nil, &RangeError{"NewLine", "section " + s.Name + " TempMaxOp", "<", "TC_MIN",
+				TC_MIN, s.TempMaxOp}
+		}
+		if s.TempMaxOp > TC_MAX {
+			return nil, &RangeError{"NewLine", "section " + s.Name + " TempMaxOp", ">", "TC_MAX",
+				TC_MAX, s.TempMaxOp}
+		}
+		if s.Length < 0 {
+			return nil, &RangeError{"NewLine", "section " + s.Name + " Length", "<", "0", 0,
+				s.Length}
+		}
+		if s.Span <= 0 {
+			return nil, &RangeError{"NewLine", "section " + s.Name + " Span", "<=", "0", 0, s.Span}
+		}
+		cc, err := NewCurrentCalc(s.Conductor)
+		if err != nil {
+			return nil, &OpError{"NewLine: section " + s.Name, err}
+		}
+		if err := cc.SetAltitude(s.Altitude); err != nil {
+			return nil, &OpError{"NewLine: section " + s.Name, err}
+		}
+		calcs[i] = cc
+	}
+	list := make([]LineSection, len(sections))
+	for i, s := range sections {
+		list[i] = *s
+	}
+	return &Line{list, calcs, id}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// Line Transmission line composed of ordered sections in series. The rating of the line
+// is the minimum phase current of its sections. Line keeps a copy of each LineSection,
+// while WeatherZones are shared. Rating and RatingAt are safe for concurrent use as long
+// as the zones are not updated at the same time (see WeatherZone).
+type Line struct {
+	sections []LineSection  // Ordered sections
+	calcs    []*CurrentCalc // *CurrentCalc of each section (altitude set)
+	id       string         // Database id
+}
+
+// SectionRating Rating of one LineSection
+type SectionRating struct {
+	Section   string  // Name of the section
+	Zone      string  // Name of the weather zone
+	Ta        float64 // Ambient temperature of the zone [°C]
+	WindAngle float64 // Angle between air stream and conductor axis [°]
+	Current   float64 // Current of each subconductor at TempMaxOp [A]
+	Phase     float64 // Current of the phase Nsc*Current [A]
+}
+
+// LineRating Rating of a Line
+type LineRating struct {
+	Current  float64         // Rating of the line, minimum of section Phase currents [A]
+	Critical int             // Index of the section that limits the line
+	Sections []SectionRating // Rating of each section in line order
+}
+
+// Rating Returns the rating of every section with the current values of its weather zone
+// and the rating of the line. The first section with the minimum rating is the critical
+// section.
+func (l *Line) Rating() (*LineRating, error) {
+	return l.rating("Line.Rating", math.NaN())
+}
+
+// RatingAt Returns the rating like Rating but with ambient temperature ta [°C] in every
+// weather zone
+func (l *Line) RatingAt(ta float64) (*LineRating, error) {
+	return l.rating("Line.RatingAt", ta)
+}
+
+// rating Returns the rating of the line. A NaN ta uses the temperature of each zone.
+func (l *Line) rating(op string, ta float64) (*LineRating, error) {
+	lr := &LineRating{Sections: make([]SectionRating, len(l.sections))}
+	for i := range l.sections {
+		s := &l.sections[i]
+		t := ta
+		if math.IsNaN(t) {
+			t = s.Zone.Ta
+		}
+		sr, err := l.sectionRating(i, t)
+		if err != nil {
+			return nil, &OpError{op + ": section " + s.Name, err}
+		}
+		lr.Sections[i] = sr
+		if i == 0 || sr.Phase < lr.Current {
+			lr.Current = sr.Phase
+			lr.Critical = i
+		}
+	}
+	return lr, nil
+}
+
+// sectionRating Returns the rating of section i at ambient temperature ta
+func (l *Line) sectionRating(i int, ta float64) (SectionRating, error) {
+	s := &l.sections[i]
+	z := s.Zone
+	sr := SectionRating{Section: s.Name, Zone: z.Name, Ta: ta, WindAngle: s.windAngle()}
+	cc, err := l.calcs[i].WithConditions(Conditions{s.Altitude, z.AirVelocity, sr.WindAngle,
+		z.SunEffect, z.Emissivity})
+	if err != nil {
+		return sr, err
+	}
+	if sr.Current, err = cc.Current(ta, s.TempMaxOp); err != nil {
+		return sr, err
+	}
+	sr.Phase = sr.Current * float64(s.Nsc)
+	return sr, nil
+}
+
+// SagTensionCalc Returns *SagTensionCalc of the ruling span of section i with reference
+// state t0 [°C] and h0 [kg], e.g. the stringing state of the section
+func (l *Line) SagTensionCalc(i int, t0 float64, h0 float64) (*SagTensionCalc, error) {
+	s := &l.sections[i]
+	st, err := NewSagTensionCalc(s.Conductor, s.Span, t0, h0)
+	if err != nil {
+		return nil, &OpError{"Line.SagTensionCalc: section " + s.Name, err}
+	}
+	return st, nil
+}
+
+// Section Returns a copy of section i
+func (l *Line) Section(i int) LineSection {
+	return l.sections[i]
+}
+
+// Length Returns the sum of section lengths [m]
+func (l *Line) Length() float64 {
+	total := 0.0
+	for _, s := range l.sections {
+		total += s.Length
+	}
+	return total
+}
+
+func (l *Line) Len() int {
+	return len(l.sections)
+}
+
+func (l *Line) Id() string {
+	return l.id
+}
@@ -0,0 +1,164 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getLine() (*Line, *WeatherZone, *WeatherZone) {
+	valley := &WeatherZone{"VALLEY", 30, 2, 0, 1, 0.5}
+	mountain := &WeatherZone{"MOUNTAIN", 15, 2, 0, 1, 0.5}
+	flint := getValidConductor()
+	sections := []*LineSection{
+		{"S1", flint, 1, 75, 12000, 350, 90, 300, valley},
+		{"S2", flint, 1, 75, 8000, 450, 45, 2500, mountain},
+		{"S3", flint, 2, 50, 3000, 300, 0, 500, valley},
+	}
+	line, _ := NewLine(sections, "LINE-1")
+	return line, valley, mountain
+}
+
+func Test_NewLine(t *testing.T) {
+	line, valley, _ := getLine()
+	if line.Id() != "LINE-1" || line.Len() != 3 || line.Length() != 23000 {
+		t.Error("Line values !=")
+	}
+	if s := line.Section(1); s.Name != "S2" || s.Altitude != 2500 {
+		t.Error("Section values !=")
+	}
+
+	flint := getValidConductor()
+	ok := LineSection{"S", flint, 1, 75, 1000, 300, 0, 300, valley}
+	if _, err := NewLine(nil, ""); err == nil {
+		t.Error("len(sections)<1 error expected")
+	}
+	if _, err := NewLine([]*LineSection{&ok, nil}, ""); err == nil {
+		t.Error("Nil section error expected")
+	}
+	for _, f := range []func(s *LineSection){
+		func(s *LineSection) { s.Zone = nil },
+		func(s *LineSection) { s.Conductor = nil },
+		func(s *LineSection) { s.Nsc = 0 },
+		func(s *LineSection) { s.TempMaxOp = TC_MAX + 1 },
+		func(s *LineSection) { s.Length = -1 },
+		func(s *LineSection) { s.Span = 0 },
+		func(s *LineSection) { s.Altitude = -1 },
+	} {
+		bad := ok
+		f(&bad)
+		if _, err := NewLine([]*LineSection{&bad}, ""); err == nil {
+			t.Errorf("Error expected for %+v", bad)
+		}
+	}
+}
+
+func Test_LineSection_WindAngle(t *testing.T) {
+	z := &WeatherZone{}
+	s := LineSection{Zone: z}
+	for _, x := range [][3]float64{{0, 0, 0}, {90, 0, 90}, {45, 0, 45}, {0, 135, 45},
+		{350, 10, 20}, {270, 90, 0}, {200, 90, 70}} {
+		z.WindAzimuth, s.Azimuth = x[0], x[1]
+		if math.Abs(s.windAngle()-x[2]) > 1e-9 {
+			t.Errorf("WindAngle %f expected got %f", x[2], s.windAngle())
+		}
+	}
+}
+
+func Test_Line_Rating(t *testing.T) {
+	line, valley, mountain := getLine()
+	lr, err := line.Rating()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lr.Sections) != 3 {
+		t.Fatal("3 sections expected")
+	}
+	min := math.Inf(1)
+	for i, sr := range lr.Sections {
+		s := line.Section(i)
+		cc, _ := NewCurrentCalc(s.Conductor)
+		cc.SetConditions(Conditions{s.Altitude, s.Zone.AirVelocity, s.windAngle(),
+			s.Zone.SunEffect, s.Zone.Emissivity})
+		cur, _ := cc.Current(s.Zone.Ta, s.TempMaxOp)
+		if sr.Current != cur || sr.Phase != cur*float64(s.Nsc) || sr.Ta != s.Zone.Ta {
+			t.Errorf("Section %s rating %f expected got %+v", s.Name, cur, sr)
+		}
+		min = math.Min(min, sr.Phase)
+	}
+	if lr.Current != min || lr.Sections[lr.Critical].Phase != min {
+		t.Errorf("Minimum %f expected got %+v", min, lr)
+	}
+	// Wind parallel to S3 with lower TempMaxOp
+	if lr.Critical != 2 {
+		t.Errorf("Critical section S3 expected got %d", lr.Critical)
+	}
+
+	// Zones are shared: a calm valley reduces S1 rating
+	s1 := lr.Sections[0].Current
+	valley.AirVelocity = 0
+	lr, _ = line.Rating()
+	if lr.Sections[0].Current >= s1 {
+		t.Errorf("Current < %f expected got %f", s1, lr.Sections[0].Current)
+	}
+	valley.AirVelocity = 2
+
+	mountain.SunEffect = 2
+	if _, err := line.Rating(); err == nil {
+		t.Error("SunEffect>1 error expected")
+	}
+	mountain.SunEffect = 1
+
+	lr, _ = line.RatingAt(40)
+	for _, sr := range lr.Sections {
+		if sr.Ta != 40 {
+			t.Errorf("Ta 40 expected got %f", sr.Ta)
+		}
+	}
+	if _, err := line.RatingAt(TA_MAX + 1); err == nil {
+		t.Error("Ta>TA_MAX error expected")
+	}
+}
+
+func Test_Line_SagTensionCalc(t *testing.T) {
+	line, _, _ := getLine()
+	for i := 0; i < line.Len(); i++ {
+		s := line.Section(i)
+		st, err := line.SagTensionCalc(i, 15, 2000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Conductor() != s.Conductor || st.Span() != s.Span || st.T0() != 15 ||
+			st.H0() != 2000 {
+			t.Errorf("Section %s ruling span expected got %+v", s.Name, st)
+		}
+	}
+	s1, _ := line.SagTensionCalc(0, 15, 2000)
+	s2, _ := line.SagTensionCalc(1, 15, 2000)
+	if a, b := s1.SagAt(2000), s2.SagAt(2000); a >= b {
+		t.Errorf("Longer ruling span of S2 sags more expected got %f >= %f", a, b)
+	}
+	if _, err := line.SagTensionCalc(0, 15, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func ExampleLine_Rating() {
+	line, _, _ := getLine()
+	lr, _ := line.Rating()
+	for _, sr := range lr.Sections {
+		fmt.Printf("%s %-8s %4.1f°C %2.0f° %6.1f A\n", sr.Section, sr.Zone, sr.Ta, sr.WindAngle,
+			sr.Phase)
+	}
+	fmt.Printf("Rating %.1f A, critical %s\n", lr.Current, lr.Sections[lr.Critical].Section)
+	// Output:
+	// S1 VALLEY   30.0°C 90°  748.8 A
+	// S2 MOUNTAIN 15.0°C 45°  767.7 A
+	// S3 VALLEY   30.0°C  0°  349.4 A
+	// Rating 349.4 A, critical S3
+}
@@ -0,0 +1,291 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Constants for line parameters
+//
+//	MU0_2PI   = 2e-4       Permeability of vacuum / 2π [H/km]
+//	EPS0      = 8.854e-12  Permittivity of vacuum [F/m]
+//	GMR_SOLID = 0.7788     GMR / radius of a solid round conductor e^(-1/4)
+const (
+	MU0_2PI   = 2e-4
+	EPS0      = 8.854187817e-12
+	GMR_SOLID = 0.7788007830714049
+)
+
+//----------------------------------------------------------------------------------------
+
+// GMR Returns the geometric mean radius of the stranding [mm]. Wires are placed on the
+// circles of their layers and weighted by their conductance, so non conducting cores do
+// not count and steel cores count little.
+func (s *Stranding) GMR() float64 {
+	type wire struct{ x, y, r, w float64 }
+	var wires []wire
+	total := 0.0
+	radius := 0.0 // Outer radius of previous layers
+	for i := range s.layers {
+		sl := &s.layers[i]
+		r := sl.Diameter / 2
+		var rl float64 // Radius of wire centers
+		switch {
+		case i == 0 && sl.Wires == 1:
+			rl = 0
+		case i == 0:
+			rl = r / math.Sin(math.Pi/float64(sl.Wires))
+		case sl.Shape == WS_TRAPEZOIDAL:
+			rl = radius + sl.Thickness/2
+		default:
+			rl = radius + r
+		}
+		radius += sl.depth(i == 0) / 2
+		w := sl.Material.conductance(sl.wireArea(), 1, 20)
+		for k := 0; k < sl.Wires; k++ {
+			a := 2 * math.Pi * float64(k) / float64(sl.Wires)
+			wires = append(wires, wire{rl * math.Cos(a), rl * math.Sin(a), r, w})
+			total += w
+		}
+	}
+	lng := 0.0
+	for i, a := range wires {
+		for j, b := range wires {
+			var d float64
+			if i == j {
+				d = GMR_SOLID * a.r
+			} else {
+				d = math.Hypot(a.x-b.x, a.y-b.y)
+			}
+			lng += a.w * b.w * math.Log(d)
+		}
+	}
+	return math.Exp(lng / (total * total))
+}
+
+//----------------------------------------------------------------------------------------
+
+// LineWire Phase bundle or shield wire of a LineGeometry. Subconductors of a bundle are
+// at the vertices of a regular polygon.
+type LineWire struct {
+	Conductor *Conductor // *Conductor of subconductors
+	X         float64    // Horizontal position of the bundle center [m]
+	Y         float64    // Mean height above ground of the bundle center [m]
+	Nsc       int        // Number of subconductors (1 for single conductors and shield wires)
+	Spacing   float64    // Distance between adjacent subconductors [m]
+	GMR       float64    // GMR of a subconductor [mm] (0 uses GMR_SOLID*radius)
+}
+
+// BundleGMR Returns the geometric mean radius of the bundle [m]
+func (lw *LineWire) BundleGMR() float64 {
+	gmr := lw.GMR
+	if gmr == 0 {
+		gmr = GMR_SOLID * lw.Conductor.diameter / 2
+	}
+	return bundleRadius(gmr/1000, lw.Nsc, lw.Spacing)
+}
+
+// BundleRadius Returns the equivalent radius of the bundle for capacitance [m]
+func (lw *LineWire) BundleRadius() float64 {
+	return bundleRadius(lw.Conductor.diameter/2000, lw.Nsc, lw.Spacing)
+}
+
+// bundleRadius Returns (n*r*R^(n-1))^(1/n), R radius of the polygon with n vertices and
+// side s
+func bundleRadius(r float64, n int, s float64) float64 {
+	if n == 1 {
+		return r
+	}
+	rb := s / (2 * math.Sin(math.Pi/float64(n)))
+	return math.Pow(float64(n)*r*math.Pow(rb, float64(n-1)), 1/float64(n))
+}
+
+// resistance Returns the resistance of the bundle at temperature t [Ohm/km]
+func (lw *LineWire) resistance(t float64) float64 {
+	c := lw.Conductor
+	return c.r25 * (1 + c.category.alpha*(t-25)) / float64(lw.Nsc)
+}
+
+//----------------------------------------------------------------------------------------
+
+// NewLineGeometry Returns *LineGeometry object
+// phases    []LineWire : Phases a, b and c of each circuit (3 per circuit)
+// shields   []LineWire : Shield wires grounded at every tower (may be empty)
+// frequency float64    : System frequency [Hz] (required frequency > 0)
+func NewLineGeometry(phases []LineWire, shields []LineWire,
+	frequency float64) (*LineGeometry, error) {
+	if len(phases) < 3 || len(phases)%3 != 0 {
+		return nil, &ConfigError{"NewLineGeometry", "len(phases)", "must be a multiple of 3"}
+	}
+	if frequency <= 0 {
+		return nil, &RangeError{"NewLineGeometry", "frequency", "<=", "0", 0, frequency}
+	}
+	wires := make([]LineWire, 0, len(phases)+len(shields))
+	wires = append(wires, phases...)
+	wires = append(wires, shields...)
+	for i, w := range wires {
+		field := "phase " + strconv.Itoa(i)
+		if i >= len(phases) {
+			field = "shield " + strconv.Itoa(i-len(phases))
+		}
+		if w.Conductor == nil {
+			return nil, &ConfigError{"NewLineGeometry", field + " Conductor", "== nil"}
+		}
+		if w.Conductor.category == nil {
+			return nil, &ConfigError{"NewLineGeometry", field + " Conductor.Category", "== nil"}
+		}
+		if w.Conductor.diameter <= 0 {
+			return nil, &RangeError{"NewLineGeometry", field + " Conductor.Diameter", "<=", "0",
+				0, w.Conductor.diameter}
+		}
+		if w.Y <= 0 {
+			return nil, &RangeError{"NewLineGeometry", field + " Y", "<=", "0", 0, w.Y}
+		}
+		if w.Nsc < 1 {
+			return nil, &RangeError{"NewLineGeometry", field + " Nsc", "<", "1", 1,
+				float64(w.Nsc)}
+		}
+		if w.Nsc > 1 && w.Spacing <= 0 {
+			return nil, &RangeError{"NewLineGeometry", field + " Spacing", "<=", "0", 0,
+				w.Spacing}
+		}
+		if w.GMR < 0 {
+			return nil, &RangeError{"NewLineGeometry", field + " GMR", "<", "0", 0, w.GMR}
+		}
+		for j := 0; j < i; j++ {
+			if wires[j].X == w.X && wires[j].Y == w.Y {
+				return nil, &ConfigError{"NewLineGeometry", field, "at the position of other wire"}
+			}
+		}
+	}
+	return &LineGeometry{wires, len(phases), frequency}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// LineGeometry Arrangement of the wires of an overhead line on the tower. Calculates
+// series impedance and shunt admittance per km assuming a transposed line.
+type LineGeometry struct {
+	wires     []LineWire // Phases followed by shield wires
+	nphases   int        // Number of phases
+	frequency float64    // System frequency [Hz]
+}
+
+// LineParams Sequence parameters of a transposed line per km
+type LineParams struct {
+	R float64 // Series resistance [Ohm/km]
+	X float64 // Series reactance [Ohm/km]
+	B float64 // Shunt susceptance [S/km]
+}
+
+// Z Returns the series impedance R + jX [Ohm/km]
+func (lp *LineParams) Z() complex128 {
+	return complex(lp.R, lp.X)
+}
+
+// PositiveSequence Returns the positive sequence parameters with conductor temperature t
+// [°C], the mean of the circuits. Uses images in a perfect conducting earth; shield wires
+// are eliminated with Kron reduction (see Sequence for the earth return path).
+func (lg *LineGeometry) PositiveSequence(t float64) (*LineParams, error) {
+	if t < TC_MIN {
+		return nil, &RangeError{"LineGeometry.PositiveSequence", "t", "<", "TC_MIN", TC_MIN, t}
+	}
+	if t > TC_MAX {
+		return nil, &RangeError{"LineGeometry.PositiveSequence", "t", ">", "TC_MAX", TC_MAX, t}
+	}
+	omega := 2 * math.Pi * lg.frequency
+	z := newCMatrix(len(lg.wires))
+	for i := range lg.wires {
+		for j := range lg.wires {
+			l := MU0_2PI * lg.logRatio(i, j, lg.wires[i].BundleGMR())
+			z[i][j] = complex(0, omega*l)
+		}
+		z[i][i] += complex(lg.wires[i].resistance(t), 0)
+	}
+	zr, ok := z.kron(lg.nphases)
+	if !ok {
+		return nil, &ConfigError{"LineGeometry.PositiveSequence", "shields", "singular matrix"}
+	}
+	c, err := lg.capacitance("LineGeometry.PositiveSequence")
+	if err != nil {
+		return nil, err
+	}
+	var z1, c1 complex128
+	n := complex(float64(lg.Circuits()), 0)
+	for k := 0; k < lg.Circuits(); k++ {
+		s, m := selfMutual(zr, k, k)
+		z1 += (s - m) / n
+		s, m = selfMutual(c, k, k)
+		c1 += (s - m) / n
+	}
+	return &LineParams{real(z1), imag(z1), omega * real(c1)}, nil
+}
+
+// capacitance Returns the capacitance matrix of the phases [F/km] with shield wires
+// grounded
+func (lg *LineGeometry) capacitance(op string) (cmatrix, error) {
+	p := newCMatrix(len(lg.wires))
+	for i := range lg.wires {
+		for j := range lg.wires {
+			p[i][j] = complex(lg.logRatio(i, j, lg.wires[i].BundleRadius())/(2*math.Pi*EPS0)/
+				1000, 0) // [km/F]
+		}
+	}
+	pr, ok := p.kron(lg.nphases)
+	if !ok {
+		return nil, &ConfigError{op, "shields", "singular matrix"}
+	}
+	c, ok := pr.inverse()
+	if !ok {
+		return nil, &ConfigError{op, "phases", "singular matrix"}
+	}
+	return c, nil
+}
+
+// logRatio Returns ln(D'/d) between wires i and j, D' distance from i to the image of j.
+// Uses radius r [m] for i == j.
+func (lg *LineGeometry) logRatio(i int, j int, r float64) float64 {
+	a, b := &lg.wires[i], &lg.wires[j]
+	if i == j {
+		return math.Log(2 * a.Y / r)
+	}
+	dx := a.X - b.X
+	return math.Log(math.Hypot(dx, a.Y+b.Y) / math.Hypot(dx, a.Y-b.Y))
+}
+
+// selfMutual Returns the mean of diagonal and off diagonal elements of the 3 x 3 block of
+// m between circuits a and b. For a != b every element is mutual and s == m.
+func selfMutual(m cmatrix, a int, b int) (complex128, complex128) {
+	var s, mu complex128
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if i == j {
+				s += m[3*a+i][3*b+j]
+			} else {
+				mu += m[3*a+i][3*b+j]
+			}
+		}
+	}
+	if a != b {
+		return (s + mu) / 9, (s + mu) / 9
+	}
+	return s / 3, mu / 6
+}
+
+// Wire Returns a copy of wire i (phases first, then shield wires)
+func (lg *LineGeometry) Wire(i int) LineWire {
+	return lg.wires[i]
+}
+
+// Circuits Returns the number of circuits
+func (lg *LineGeometry) Circuits() int {
+	return lg.nphases / 3
+}
+
+func (lg *LineGeometry) Frequency() float64 {
+	return lg.frequency
+}
@@ -0,0 +1,103 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// getLineGeometry Returns a 220 kV horizontal line with DRAKE conductors
+func getLineGeometry(nsc int, shields []LineWire) *LineGeometry {
+	s := getStranding()
+	c := s.Conductor(CC_ACSR)
+	gmr := s.GMR()
+	lg, _ := NewLineGeometry([]LineWire{
+		{c, -7, 20, nsc, 0.45, gmr},
+		{c, 0, 20, nsc, 0.45, gmr},
+		{c, 7, 20, nsc, 0.45, gmr},
+	}, shields, 50)
+	return lg
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_LineParams_GMR(t *testing.T) {
+	// DRAKE GMR 0.0375 ft in data sheets
+	if gmr := getStranding().GMR(); math.Abs(gmr-11.43) > 0.2 {
+		t.Errorf("11.43 mm expected got %f", gmr)
+	}
+	// Single round wire
+	s, _ := NewStranding("W", []StrandLayer{{1, 10, MT_CU, 0, WS_ROUND, 0}}, "")
+	if gmr := s.GMR(); math.Abs(gmr-5*GMR_SOLID) > 1e-9 {
+		t.Errorf("%f expected got %f", 5*GMR_SOLID, gmr)
+	}
+	lw := LineWire{getConductor(), 0, 20, 2, 0.4, 10}
+	if r := lw.BundleGMR(); math.Abs(r-math.Sqrt(0.01*0.4)) > 1e-12 {
+		t.Errorf("%f expected got %f", math.Sqrt(0.01*0.4), r)
+	}
+}
+
+func Test_LineParams_PositiveSequence(t *testing.T) {
+	lg := getLineGeometry(1, nil)
+	lp, err := lg.PositiveSequence(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// X = ω·2e-4·ln(GMD/GMR) with GMD 8.82 m
+	x := 2 * math.Pi * 50 * MU0_2PI * math.Log(math.Cbrt(7*7*14)/0.01143)
+	if math.Abs(lp.X-x) > 0.01 || lp.B < 2.5e-6 || lp.B > 3e-6 {
+		t.Errorf("X %f and B about 2.7e-6 expected got %+v", x, lp)
+	}
+	c := lg.Wire(0).Conductor
+	if math.Abs(lp.R-c.R25()*(1+c.Category().Alpha()*25)) > 1e-12 {
+		t.Errorf("R at 50°C expected got %f", lp.R)
+	}
+
+	// Bundles lower reactance and raise susceptance
+	lp2, _ := getLineGeometry(2, nil).PositiveSequence(50)
+	if lp2.X >= lp.X || lp2.B <= lp.B || math.Abs(lp2.R-lp.R/2) > 1e-12 {
+		t.Errorf("Bundle parameters expected got %+v", lp2)
+	}
+
+	// Shield wires have a small effect in positive sequence
+	shield := NewConductor("OPGW", CC_ACSR, 14, 100, 0.5, 8000, 0.4, 0, "")
+	lp3, _ := getLineGeometry(1, []LineWire{{shield, -4, 28, 1, 0, 0},
+		{shield, 4, 28, 1, 0, 0}}).PositiveSequence(50)
+	if math.Abs(lp3.X-lp.X) > 0.01 || lp3.B <= lp.B || math.Abs(lp3.B-lp.B) > 0.1*lp.B {
+		t.Errorf("Close parameters expected got %+v", lp3)
+	}
+}
+
+func Test_LineParams_Errors(t *testing.T) {
+	c := getConductor()
+	tests := []struct {
+		phases    []LineWire
+		frequency float64
+	}{
+		{[]LineWire{{c, 0, 20, 1, 0, 0}}, 50},
+		{[]LineWire{{c, 0, 20, 1, 0, 0}, {c, 5, 20, 1, 0, 0}, {c, 10, 20, 1, 0, 0}}, 0},
+		{[]LineWire{{nil, 0, 20, 1, 0, 0}, {c, 5, 20, 1, 0, 0}, {c, 10, 20, 1, 0, 0}}, 50},
+		{[]LineWire{{c, 0, 0, 1, 0, 0}, {c, 5, 20, 1, 0, 0}, {c, 10, 20, 1, 0, 0}}, 50},
+		{[]LineWire{{c, 0, 20, 2, 0, 0}, {c, 5, 20, 1, 0, 0}, {c, 10, 20, 1, 0, 0}}, 50},
+		{[]LineWire{{c, 0, 20, 1, 0, 0}, {c, 0, 20, 1, 0, 0}, {c, 10, 20, 1, 0, 0}}, 50},
+	}
+	for i, x := range tests {
+		if _, err := NewLineGeometry(x.phases, nil, x.frequency); !errors.Is(err,
+			ErrInvalidConfig) && !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%d: error expected got %v", i, err)
+		}
+	}
+	if _, err := getLineGeometry(1, nil).PositiveSequence(3000); !errors.Is(err,
+		ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+func Example_LineGeometry_PositiveSequence() {
+	lp, _ := getLineGeometry(2, nil).PositiveSequence(50)
+	fmt.Printf("R=%.4f X=%.4f Ohm/km B=%.3f uS/km\n", lp.R, lp.X, lp.B*1e6)
+	// Output: R=0.0398 X=0.3007 Ohm/km B=3.773 uS/km
+}
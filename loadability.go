@@ -0,0 +1,193 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Limits of line loadability
+// LL_THERMAL   = "THERMAL"    Thermal rating of the conductors
+// LL_VOLTAGE   = "VOLTAGE"    Voltage drop at the receiving end
+// LL_STABILITY = "STABILITY"  Steady state stability margin
+const (
+	LL_THERMAL   = "THERMAL"
+	LL_VOLTAGE   = "VOLTAGE"
+	LL_STABILITY = "STABILITY"
+)
+
+// SurgeImpedance Returns the surge impedance of the lossless line sqrt(X/B) [Ohm]
+func (lp *LineParams) SurgeImpedance() float64 {
+	return math.Sqrt(lp.X / lp.B)
+}
+
+// SIL Returns the surge impedance loading [MW]
+// voltage float64 : Line to line voltage [kV]
+func (lp *LineParams) SIL(voltage float64) float64 {
+	return voltage * voltage / lp.SurgeImpedance()
+}
+
+//----------------------------------------------------------------------------------------
+
+// NewLoadabilityCalc Returns *LoadabilityCalc object with maximum voltage drop 5% and
+// stability angle 44° (30% stability margin)
+// params  *LineParams     : Positive sequence parameters of the line (X > 0, B > 0)
+// voltage float64         : Line to line voltage [kV] (required voltage > 0)
+// table   *OperatingTable : Thermal limit, the lowest Nsc*Current of its items
+// ta      float64         : Ambient temperature for the thermal limit [°C]
+func NewLoadabilityCalc(params *LineParams, voltage float64, table *OperatingTable,
+	ta float64) (*LoadabilityCalc, error) {
+	op := "NewLoadabilityCalc"
+	if params == nil {
+		return nil, &ConfigError{op, "params", "== nil"}
+	}
+	if params.X <= 0 {
+		return nil, &RangeError{op, "params.X", "<=", "0", 0, params.X}
+	}
+	if params.B <= 0 {
+		return nil, &RangeError{op, "params.B", "<=", "0", 0, params.B}
+	}
+	if params.R < 0 {
+		return nil, &RangeError{op, "params.R", "<", "0", 0, params.R}
+	}
+	if voltage <= 0 {
+		return nil, &RangeError{op, "voltage", "<=", "0", 0, voltage}
+	}
+	if table == nil {
+		return nil, &ConfigError{op, "table", "== nil"}
+	}
+	current := math.Inf(1)
+	for _, item := range table.Items() {
+		i, err := item.Current(ta)
+		if err != nil {
+			return nil, &OpError{op, err}
+		}
+		current = math.Min(current, i*float64(item.Nsc()))
+	}
+	thermal := math.Sqrt(3) * voltage * current / 1000
+	return &LoadabilityCalc{*params, voltage, thermal, 0.05, 44}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// LoadabilityCalc Object to calculate the St. Clair loadability curve of a line between
+// infinite buses with the exact long line model. The voltage drop limit delivers power at
+// unity power factor without reactive support at the receiving end; the stability limit
+// uses the same voltage at both ends.
+type LoadabilityCalc struct {
+	params      LineParams // Positive sequence parameters per km
+	voltage     float64    // Line to line voltage [kV]
+	thermal     float64    // Thermal limit [MW]
+	voltageDrop float64    // Maximum voltage drop (fraction of voltage)
+	angle       float64    // Maximum angle between line ends [°]
+}
+
+// LoadabilityPoint Limits of a line with length Length
+type LoadabilityPoint struct {
+	Length    float64 // Line length [km]
+	Thermal   float64 // Thermal limit [MW]
+	Voltage   float64 // Voltage drop limit [MW]
+	Stability float64 // Stability limit [MW]
+	Limit     float64 // Loadability, the lowest limit [MW]
+	Limiting  string  // LL_THERMAL, LL_VOLTAGE or LL_STABILITY
+}
+
+// At Returns the limits of a line with length [km] (required length > 0)
+func (lc *LoadabilityCalc) At(length float64) (*LoadabilityPoint, error) {
+	if length <= 0 {
+		return nil, &RangeError{"LoadabilityCalc.At", "length", "<=", "0", 0, length}
+	}
+	a, b := lc.abcd(length)
+	v := lc.voltage / math.Sqrt(3) // Phase voltage [kV]
+
+	// Receiving end power with |Vs| = |Vr| = v and angle δ
+	beta, alpha := cmplx.Phase(b), cmplx.Phase(a)
+	delta := lc.angle * math.Pi / 180
+	stability := 3 * v * v / cmplx.Abs(b) * (math.Cos(beta-delta) - cmplx.Abs(a)*math.Cos(beta-alpha))
+
+	// |A·Vr + B·Ir| = Vs with Ir in phase with Vr
+	vr := complex(v*(1-lc.voltageDrop), 0)
+	qa := real(b * cmplx.Conj(b))
+	qb := 2 * real(a*vr*cmplx.Conj(b))
+	qc := real(a*vr*cmplx.Conj(a*vr)) - v*v
+	voltage := 0.0
+	if disc := qb*qb - 4*qa*qc; disc >= 0 {
+		ir := (-qb + math.Sqrt(disc)) / (2 * qa) // [kA]
+		voltage = math.Max(3*real(vr)*ir, 0)
+	}
+
+	lp := &LoadabilityPoint{length, lc.thermal, voltage, stability, lc.thermal, LL_THERMAL}
+	if voltage < lp.Limit {
+		lp.Limit, lp.Limiting = voltage, LL_VOLTAGE
+	}
+	if stability < lp.Limit {
+		lp.Limit, lp.Limiting = stability, LL_STABILITY
+	}
+	return lp, nil
+}
+
+// Curve Returns the limits for every length of lengths [km]
+func (lc *LoadabilityCalc) Curve(lengths []float64) ([]LoadabilityPoint, error) {
+	curve := make([]LoadabilityPoint, len(lengths))
+	for i, l := range lengths {
+		lp, err := lc.At(l)
+		if err != nil {
+			return nil, &OpError{"LoadabilityCalc.Curve", err}
+		}
+		curve[i] = *lp
+	}
+	return curve, nil
+}
+
+// abcd Returns A and B constants of the long line with length [km]
+func (lc *LoadabilityCalc) abcd(length float64) (complex128, complex128) {
+	z := lc.params.Z()
+	y := complex(0, lc.params.B)
+	gl := cmplx.Sqrt(z*y) * complex(length, 0)
+	zc := cmplx.Sqrt(z / y)
+	return cmplx.Cosh(gl), zc * cmplx.Sinh(gl)
+}
+
+// SIL Returns the surge impedance loading of the line [MW]
+func (lc *LoadabilityCalc) SIL() float64 {
+	return lc.params.SIL(lc.voltage)
+}
+
+func (lc *LoadabilityCalc) Thermal() float64 {
+	return lc.thermal
+}
+
+func (lc *LoadabilityCalc) VoltageDrop() float64 {
+	return lc.voltageDrop
+}
+
+// SetVoltageDrop Sets the maximum voltage drop d (0 < d < 1)
+func (lc *LoadabilityCalc) SetVoltageDrop(d float64) error {
+	if d <= 0 {
+		return &RangeError{"LoadabilityCalc.SetVoltageDrop", "d", "<=", "0", 0, d}
+	}
+	if d >= 1 {
+		return &RangeError{"LoadabilityCalc.SetVoltageDrop", "d", ">=", "1", 1, d}
+	}
+	lc.voltageDrop = d
+	return nil
+}
+
+func (lc *LoadabilityCalc) Angle() float64 {
+	return lc.angle
+}
+
+// SetAngle Sets the maximum angle a [°] between line ends (0 < a < 90)
+func (lc *LoadabilityCalc) SetAngle(a float64) error {
+	if a <= 0 {
+		return &RangeError{"LoadabilityCalc.SetAngle", "a", "<=", "0", 0, a}
+	}
+	if a >= 90 {
+		return &RangeError{"LoadabilityCalc.SetAngle", "a", ">=", "90", 90, a}
+	}
+	lc.angle = a
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// getLoadabilityCalc Returns *LoadabilityCalc for a 500 kV line with 3 x DRAKE
+func getLoadabilityCalc() *LoadabilityCalc {
+	lp, _ := get500kV(3).PositiveSequence(75)
+	cc, _ := NewCurrentCalc(getStranding().Conductor(CC_ACSR))
+	item, _ := NewOperatingItem(cc, 75, 3)
+	ot, _ := NewOperatingTable([]*OperatingItem{item}, "500 kV")
+	lc, _ := NewLoadabilityCalc(lp, 500, ot, 25)
+	return lc
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Loadability_SIL(t *testing.T) {
+	lp := &LineParams{0.03, 0.3, 4e-6}
+	if zc := lp.SurgeImpedance(); math.Abs(zc-math.Sqrt(0.3/4e-6)) > 1e-9 {
+		t.Errorf("%f expected got %f", math.Sqrt(0.3/4e-6), zc)
+	}
+	if sil := lp.SIL(500); math.Abs(sil-500*500/lp.SurgeImpedance()) > 1e-9 {
+		t.Errorf("SIL %f", sil)
+	}
+}
+
+func Test_Loadability_Curve(t *testing.T) {
+	lc := getLoadabilityCalc()
+	curve, err := lc.Curve([]float64{50, 300, 800})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, limit := range []string{LL_THERMAL, LL_VOLTAGE, LL_VOLTAGE} {
+		if curve[i].Limiting != limit {
+			t.Errorf("%f km: %s expected got %+v", curve[i].Length, limit, curve[i])
+		}
+	}
+	for i := 1; i < len(curve); i++ {
+		if curve[i].Voltage >= curve[i-1].Voltage || curve[i].Stability >= curve[i-1].Stability {
+			t.Errorf("Decreasing limits expected got %+v", curve)
+		}
+	}
+	// Short lines carry several times SIL
+	if r := curve[0].Limit / lc.SIL(); r < 2 {
+		t.Errorf("Limit > 2 SIL expected got %f", r)
+	}
+
+	// Long lines with a lower angle are stability limited
+	lc.SetAngle(30)
+	lp, _ := lc.At(800)
+	if lp.Limiting != LL_STABILITY || lp.Limit >= lc.SIL() {
+		t.Errorf("Stability limit below SIL expected got %+v", lp)
+	}
+}
+
+func Test_Loadability_Errors(t *testing.T) {
+	lc := getLoadabilityCalc()
+	if _, err := lc.At(0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	if err := lc.SetVoltageDrop(1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	if err := lc.SetAngle(90); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	if _, err := NewLoadabilityCalc(&LineParams{0, 0, 1e-6}, 500, nil, 25); !errors.Is(err,
+		ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	lp := &LineParams{0.03, 0.3, 4e-6}
+	if _, err := NewLoadabilityCalc(lp, 500, nil, 25); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+}
+
+func Example_LoadabilityCalc() {
+	lc := getLoadabilityCalc()
+	fmt.Printf("SIL %.0f MW\n", lc.SIL())
+	curve, _ := lc.Curve([]float64{50, 100, 200, 400, 600})
+	for _, p := range curve {
+		fmt.Printf("%3.0f km %5.0f MW %s\n", p.Length, p.Limit, p.Limiting)
+	}
+	// Output:
+	// SIL 919 MW
+	//  50 km  2338 MW THERMAL
+	// 100 km  2024 MW VOLTAGE
+	// 200 km  1190 MW VOLTAGE
+	// 400 km   879 MW VOLTAGE
+	// 600 km   822 MW VOLTAGE
+}
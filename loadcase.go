@@ -0,0 +1,241 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Constants for weather loads
+//
+//	AIR_DENSITY = 1.225    Air density at 15°C and sea level [kg/m³]
+//	ICE_DENSITY = 913      Density of glaze ice, 57 lb/ft³ [kg/m³]
+const (
+	AIR_DENSITY = 1.225
+	ICE_DENSITY = 913.0
+)
+
+// Terrain categories of IEC 60826
+//
+//	TR_A = "A"  Large stretch of water upwind, flat coastal areas
+//	TR_B = "B"  Open country with very few obstacles
+//	TR_C = "C"  Terrain with numerous small obstacles of low height
+//	TR_D = "D"  Suburban areas or terrain with many tall trees
+const (
+	TR_A = "A"
+	TR_B = "B"
+	TR_C = "C"
+	TR_D = "D"
+)
+
+// terrains Roughness factor KR of each terrain category
+var terrains = map[string]float64{TR_A: 1.08, TR_B: 1.00, TR_C: 0.85, TR_D: 0.67}
+
+//----------------------------------------------------------------------------------------
+
+// LoadCase Weather load case for mechanical design. Wind acts on the diameter of the iced
+// conductor, perpendicular to the line.
+type LoadCase struct {
+	Name         string  // Name of the load case
+	Temperature  float64 // Conductor temperature [°C]
+	Ice          float64 // Radial thickness of ice [mm] (required >= 0)
+	IceDensity   float64 // Density of ice [kg/m³] (0 uses ICE_DENSITY)
+	WindPressure float64 // Wind load per projected area, factors included [Pa] (>= 0)
+	K            float64 // Constant added to the resultant (NESC) [kg/m] (>= 0)
+}
+
+// NESC loading districts of Rule 250B (combined ice and wind). Temperatures are 0°F,
+// 15°F, 30°F and 50°F.
+var (
+	NESC_HEAVY        = LoadCase{"NESC HEAVY", (0 - 32) / 1.8, 12.7, ICE_DENSITY, 191.5, 0.4464}
+	NESC_MEDIUM       = LoadCase{"NESC MEDIUM", (15 - 32) / 1.8, 6.35, ICE_DENSITY, 191.5, 0.2976}
+	NESC_LIGHT        = LoadCase{"NESC LIGHT", (30 - 32) / 1.8, 0, ICE_DENSITY, 430.9, 0.0744}
+	NESC_WARM_ISLANDS = LoadCase{"NESC WARM ISLANDS", (50 - 32) / 1.8, 0, ICE_DENSITY, 430.9,
+		0.0744}
+)
+
+// CSA C22.3 No. 1 loading conditions (ice density 900 kg/m³)
+var (
+	CSA_HEAVY    = LoadCase{"CSA HEAVY", -20, 12.5, 900, 400, 0}
+	CSA_MEDIUM_A = LoadCase{"CSA MEDIUM A", -20, 6.5, 900, 400, 0}
+	CSA_MEDIUM_B = LoadCase{"CSA MEDIUM B", -20, 12.5, 900, 300, 0}
+	CSA_LIGHT    = LoadCase{"CSA LIGHT", -20, 5, 900, 300, 0}
+)
+
+// UnitLoad Loads per unit length of a conductor for a LoadCase [kg/m]
+type UnitLoad struct {
+	Weight    float64 // Weight of the bare conductor [kg/m]
+	Ice       float64 // Weight of ice [kg/m]
+	Wind      float64 // Horizontal wind load [kg/m]
+	Resultant float64 // sqrt((Weight + Ice)² + Wind²) + K [kg/m]
+	Swing     float64 // Angle of the resultant from the vertical [°]
+}
+
+// Check Returns error if any value of lc is out of range
+func (lc LoadCase) Check() error {
+	return lc.check("LoadCase.Check")
+}
+
+func (lc LoadCase) check(op string) error {
+	if lc.Temperature < TC_MIN {
+		return &RangeError{op, "Temperature", "<", "TC_MIN", TC_MIN, lc.Temperature}
+	}
+	if lc.Temperature > TC_MAX {
+		return &RangeError{op, "Temperature", ">", "TC_MAX", TC_MAX, lc.Temperature}
+	}
+	if lc.Ice < 0 {
+		return &RangeError{op, "Ice", "<", "0", 0, lc.Ice}
+	}
+	if lc.IceDensity < 0 {
+		return &RangeError{op, "IceDensity", "<", "0", 0, lc.IceDensity}
+	}
+	if lc.WindPressure < 0 {
+		return &RangeError{op, "WindPressure", "<", "0", 0, lc.WindPressure}
+	}
+	if lc.K < 0 {
+		return &RangeError{op, "K", "<", "0", 0, lc.K}
+	}
+	return nil
+}
+
+// UnitLoad Returns the loads per unit length on conductor c from its Diameter and Weight
+func (lc LoadCase) UnitLoad(c *Conductor) (*UnitLoad, error) {
+	op := "LoadCase.UnitLoad"
+	if err := lc.check(op); err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, &ConfigError{op, "Conductor", "== nil"}
+	}
+	if c.diameter <= 0 {
+		return nil, &RangeError{op, "Conductor.Diameter", "<=", "0", 0, c.diameter}
+	}
+	if c.weight <= 0 {
+		return nil, &RangeError{op, "Conductor.Weight", "<=", "0", 0, c.weight}
+	}
+	density := lc.IceDensity
+	if density == 0 {
+		density = ICE_DENSITY
+	}
+	ul := &UnitLoad{Weight: c.weight}
+	ul.Ice = density * math.Pi * lc.Ice * (c.diameter + lc.Ice) * 1e-6
+	ul.Wind = lc.WindPressure * (c.diameter + 2*lc.Ice) / 1000 / gravity
+	v := ul.Weight + ul.Ice
+	ul.Resultant = math.Hypot(v, ul.Wind) + lc.K
+	ul.Swing = math.Atan2(ul.Wind, v) * 180 / math.Pi
+	return ul, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// IECClimate Reference climatic data of IEC 60826 for reliability based loads. Values for
+// a return period T come from Gumbel distributions fitted to the 50 years values.
+type IECClimate struct {
+	Wind     float64 // Reference wind speed, 10 min mean at 10 m, 50 years [m/s] (> 0)
+	WindCov  float64 // Coefficient of variation of yearly maximum wind (0 uses 0.12)
+	Ice      float64 // Radial thickness of ice, 50 years [mm] (>= 0)
+	IceCov   float64 // Coefficient of variation of yearly maximum ice (0 uses 0.5)
+	Terrain  string  // Terrain category TR_A, TR_B, TR_C or TR_D
+	Tau      float64 // Air density correction factor (0 uses 1)
+	Drag     float64 // Drag coefficient Cxc (0 uses 1)
+	SpanGust float64 // Combined wind factor Gc for height and Terrain (> 0)
+	SpanLen  float64 // Span factor GL for the span length (0 uses 1)
+}
+
+// gumbelFactor Returns the value for return period t [years] divided by the 50 years
+// value of a Gumbel distribution with coefficient of variation cov
+func gumbelFactor(t float64, cov float64) float64 {
+	k := func(t float64) float64 {
+		return -math.Sqrt(6) / math.Pi * (0.5772 + math.Log(math.Log(t/(t-1))))
+	}
+	return (1 + k(t)*cov) / (1 + k(50)*cov)
+}
+
+func (ic IECClimate) check(op string, t float64) error {
+	if t <= 1 {
+		return &RangeError{op, "t", "<=", "1", 1, t}
+	}
+	if ic.Wind <= 0 {
+		return &RangeError{op, "Wind", "<=", "0", 0, ic.Wind}
+	}
+	if ic.WindCov < 0 {
+		return &RangeError{op, "WindCov", "<", "0", 0, ic.WindCov}
+	}
+	if ic.Ice < 0 {
+		return &RangeError{op, "Ice", "<", "0", 0, ic.Ice}
+	}
+	if ic.IceCov < 0 {
+		return &RangeError{op, "IceCov", "<", "0", 0, ic.IceCov}
+	}
+	if _, ok := terrains[ic.Terrain]; !ok {
+		return &ConfigError{op, "Terrain", "unknown category " + ic.Terrain}
+	}
+	if ic.SpanGust <= 0 {
+		return &RangeError{op, "SpanGust", "<=", "0", 0, ic.SpanGust}
+	}
+	if ic.Tau < 0 {
+		return &RangeError{op, "Tau", "<", "0", 0, ic.Tau}
+	}
+	if ic.Drag < 0 {
+		return &RangeError{op, "Drag", "<", "0", 0, ic.Drag}
+	}
+	if ic.SpanLen < 0 {
+		return &RangeError{op, "SpanLen", "<", "0", 0, ic.SpanLen}
+	}
+	return nil
+}
+
+// WindSpeed Returns the reference wind speed KR·VR corrected by the roughness of Terrain
+// for return period t [years] (required t > 1) [m/s]
+func (ic IECClimate) WindSpeed(t float64) (float64, error) {
+	if err := ic.check("IECClimate.WindSpeed", t); err != nil {
+		return math.NaN(), err
+	}
+	return ic.windSpeed(t), nil
+}
+
+func (ic IECClimate) windSpeed(t float64) float64 {
+	return terrains[ic.Terrain] * ic.Wind * gumbelFactor(t, orDefault(ic.WindCov, 0.12))
+}
+
+// WindCase Returns the wind load case with return period t [years] and conductor
+// temperature [°C]: q0·Cxc·Gc·GL with dynamic pressure q0 = ½·τ·μ·(KR·VR)²
+func (ic IECClimate) WindCase(t float64, temperature float64) (*LoadCase, error) {
+	op := "IECClimate.WindCase"
+	if err := ic.check(op, t); err != nil {
+		return nil, err
+	}
+	v := ic.windSpeed(t)
+	q0 := 0.5 * orDefault(ic.Tau, 1) * AIR_DENSITY * v * v
+	lc := &LoadCase{"IEC 60826 WIND", temperature, 0, 0,
+		q0 * orDefault(ic.Drag, 1) * ic.SpanGust * orDefault(ic.SpanLen, 1), 0}
+	if err := lc.check(op); err != nil {
+		return nil, err
+	}
+	return lc, nil
+}
+
+// IceCase Returns the ice load case without wind with return period t [years] and
+// conductor temperature [°C]
+func (ic IECClimate) IceCase(t float64, temperature float64) (*LoadCase, error) {
+	op := "IECClimate.IceCase"
+	if err := ic.check(op, t); err != nil {
+		return nil, err
+	}
+	ice := math.Max(ic.Ice*gumbelFactor(t, orDefault(ic.IceCov, 0.5)), 0)
+	lc := &LoadCase{"IEC 60826 ICE", temperature, ice, ICE_DENSITY, 0, 0}
+	if err := lc.check(op); err != nil {
+		return nil, err
+	}
+	return lc, nil
+}
+
+// orDefault Returns x or d if x == 0
+func orDefault(x float64, d float64) float64 {
+	if x == 0 {
+		return d
+	}
+	return x
+}
@@ -0,0 +1,159 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getDrake() *Conductor {
+	cmk := ConductorMaker{"ACSR 795 MCM DRAKE", CC_ACSR, 28.14, 468.5, 1.628, 14290, 0.0718, 0,
+		""}
+	return cmk.Get()
+}
+
+func getIECClimate() IECClimate {
+	return IECClimate{Wind: 30, Ice: 10, Terrain: TR_B, SpanGust: 1.9}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_LoadCase_NESC(t *testing.T) {
+	c := getDrake()
+	// Resultants of DRAKE with the imperial formulas of Rule 250B [lb/ft]
+	for _, d := range []struct {
+		lc LoadCase
+		r  float64
+	}{{NESC_HEAVY, 2.509}, {NESC_MEDIUM, 1.808}, {NESC_LIGHT, 1.424}} {
+		ul, err := d.lc.UnitLoad(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := ul.Resultant / 1.48816; math.Abs(r-d.r) > 0.01 {
+			t.Errorf("%s: %f lb/ft expected got %f", d.lc.Name, d.r, r)
+		}
+	}
+	for _, d := range []struct {
+		lc LoadCase
+		f  float64
+	}{{NESC_HEAVY, 0}, {NESC_MEDIUM, 15}, {NESC_LIGHT, 30}, {NESC_WARM_ISLANDS, 50}} {
+		if tc, _ := Convert(d.f, UN_F, UN_C); math.Abs(d.lc.Temperature-tc) > 1e-12 {
+			t.Errorf("%s: %f°C expected got %f", d.lc.Name, tc, d.lc.Temperature)
+		}
+	}
+}
+
+func Test_LoadCase_UnitLoad(t *testing.T) {
+	c := getDrake()
+	lc := LoadCase{"CUSTOM", 0, 10, 0, 500, 0}
+	ul, err := lc.UnitLoad(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ice := ICE_DENSITY * math.Pi * 10 * (28.14 + 10) * 1e-6
+	wind := 500 * (28.14 + 20) / 1000 / gravity
+	if math.Abs(ul.Ice-ice) > 1e-12 || math.Abs(ul.Wind-wind) > 1e-12 || ul.Weight != 1.628 {
+		t.Errorf("Ice %f and wind %f expected got %+v", ice, wind, ul)
+	}
+	if r := math.Hypot(1.628+ice, wind); math.Abs(ul.Resultant-r) > 1e-12 {
+		t.Errorf("%f expected got %f", r, ul.Resultant)
+	}
+	if s := math.Atan(wind/(1.628+ice)) * 180 / math.Pi; math.Abs(ul.Swing-s) > 1e-12 {
+		t.Errorf("%f expected got %f", s, ul.Swing)
+	}
+
+	// Bare conductor without wind
+	ul, _ = LoadCase{"BARE", 15, 0, 0, 0, 0}.UnitLoad(c)
+	if ul.Resultant != c.Weight() || ul.Swing != 0 {
+		t.Errorf("Weight expected got %+v", ul)
+	}
+
+	// CSA cases are ordered by severity
+	heavy, _ := CSA_HEAVY.UnitLoad(c)
+	light, _ := CSA_LIGHT.UnitLoad(c)
+	if heavy.Resultant <= light.Resultant {
+		t.Errorf("Heavy > light expected got %f <= %f", heavy.Resultant, light.Resultant)
+	}
+}
+
+func Test_LoadCase_IEC(t *testing.T) {
+	ic := getIECClimate()
+	v50, _ := ic.WindSpeed(50)
+	if v50 != 30 {
+		t.Errorf("30 expected got %f", v50)
+	}
+	v150, _ := ic.WindSpeed(150)
+	v500, _ := ic.WindSpeed(500)
+	if v150 <= v50 || v500 <= v150 || v500 > 1.3*v50 {
+		t.Errorf("Increasing wind expected got %f %f %f", v50, v150, v500)
+	}
+	ic.Terrain = TR_A
+	if v, _ := ic.WindSpeed(50); math.Abs(v-1.08*30) > 1e-12 {
+		t.Errorf("%f expected got %f", 1.08*30, v)
+	}
+
+	ic = getIECClimate()
+	lc, err := ic.WindCase(50, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := 0.5 * AIR_DENSITY * 30 * 30 * 1.9; math.Abs(lc.WindPressure-p) > 1e-9 {
+		t.Errorf("%f expected got %f", p, lc.WindPressure)
+	}
+	ice50, _ := ic.IceCase(50, -5)
+	ice500, _ := ic.IceCase(500, -5)
+	if math.Abs(ice50.Ice-10) > 1e-12 || ice500.Ice <= ice50.Ice || ice500.WindPressure != 0 {
+		t.Errorf("Ice 10 mm and more for 500 years expected got %+v %+v", ice50, ice500)
+	}
+}
+
+func Test_LoadCase_Errors(t *testing.T) {
+	for _, lc := range []LoadCase{
+		{"", TC_MIN - 1, 0, 0, 0, 0},
+		{"", 0, -1, 0, 0, 0},
+		{"", 0, 0, -1, 0, 0},
+		{"", 0, 0, 0, -1, 0},
+		{"", 0, 0, 0, 0, -1},
+	} {
+		if err := lc.Check(); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%+v: range error expected got %v", lc, err)
+		}
+	}
+	if _, err := NESC_HEAVY.UnitLoad(nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	cmk := ConductorMaker{"", CC_ACSR, 28.14, 468.5, 0, 0, 0.0718, 0, ""}
+	if _, err := NESC_HEAVY.UnitLoad(cmk.Get()); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+
+	ic := getIECClimate()
+	if _, err := ic.WindSpeed(1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	ic.Terrain = "E"
+	if _, err := ic.WindCase(50, 15); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	ic = getIECClimate()
+	ic.SpanGust = 0
+	if _, err := ic.IceCase(50, 15); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+func Example_LoadCase_UnitLoad() {
+	c := getDrake()
+	for _, lc := range []LoadCase{NESC_HEAVY, NESC_MEDIUM, NESC_LIGHT, CSA_HEAVY} {
+		ul, _ := lc.UnitLoad(c)
+		fmt.Printf("%-12s %5.3f kg/m %4.1f°\n", lc.Name, ul.Resultant, ul.Swing)
+	}
+	// Output:
+	// NESC HEAVY   3.733 kg/m 18.5°
+	// NESC MEDIUM  2.691 kg/m 19.5°
+	// NESC LIGHT   2.119 kg/m 37.2°
+	// CSA HEAVY    3.753 kg/m 35.3°
+}
@@ -0,0 +1,142 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// LoadStep One step of a load profile: a block of a load duration curve or one record of
+// a current series
+type LoadStep struct {
+	Hours      float64     // Duration of the step [h] (required > 0)
+	Ic         float64     // Current of a subconductor [A] (required >= 0)
+	Ta         float64     // Ambient temperature [°C]
+	Conditions *Conditions // Weather of the step (nil uses CurrentCalc conditions)
+}
+
+// HourlyProfile Returns a load profile of 1 h steps
+// currents []float64 : Hourly currents of a subconductor [A]
+// tas      []float64 : Hourly ambient temperatures [°C] (one value is used for every hour)
+func HourlyProfile(currents []float64, tas []float64) ([]LoadStep, error) {
+	if len(tas) != 1 && len(tas) != len(currents) {
+		return nil, &ConfigError{"HourlyProfile", "len(tas)", "must be 1 or len(currents)"}
+	}
+	profile := make([]LoadStep, len(currents))
+	for i, ic := range currents {
+		ta := tas[0]
+		if len(tas) > 1 {
+			ta = tas[i]
+		}
+		profile[i] = LoadStep{1, ic, ta, nil}
+	}
+	return profile, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// LossStep Joule losses of one step of a load profile
+type LossStep struct {
+	Tc   float64 // Conductor temperature [°C]
+	R    float64 // Resistance at Tc [Ohm/km]
+	Loss float64 // Joule losses I²R(Tc) [kW/km]
+}
+
+// LossResult Joule losses over a load profile. Values are per km of a subconductor
+// (CurrentCalc.Losses) or of a phase (OperatingItem.Losses).
+type LossResult struct {
+	Hours      float64    // Duration of the profile [h]
+	Energy     float64    // Energy losses with R(Tc) [kWh/km]
+	EnergyR25  float64    // Energy losses with R at 25°C [kWh/km]
+	PeakLoss   float64    // Losses at the highest current of the profile [kW/km]
+	MeanLoss   float64    // Energy / Hours [kW/km]
+	LossFactor float64    // MeanLoss / PeakLoss (0 without load)
+	Steps      []LossStep // Results of every step in profile order
+}
+
+// Losses Returns the Joule losses of the conductor over a load profile. The conductor
+// temperature of each step is the steady state temperature Tc(Ta, Ic) with the step
+// weather, so losses include the increase of resistance with temperature.
+func (cc *CurrentCalc) Losses(profile []LoadStep) (*LossResult, error) {
+	return cc.losses("CurrentCalc.Losses", profile, 1)
+}
+
+// losses Returns Joule losses of n conductors in parallel carrying Ic each
+func (cc *CurrentCalc) losses(op string, profile []LoadStep, n int) (*LossResult, error) {
+	if len(profile) == 0 {
+		return nil, &ConfigError{op, "profile", "is empty"}
+	}
+	lr := &LossResult{Steps: make([]LossStep, len(profile))}
+	peak := -1.0
+	r25 := cc.conductor.r25
+	for i := range profile {
+		ls := &profile[i]
+		field := "step " + strconv.Itoa(i)
+		if ls.Hours <= 0 {
+			return nil, &RangeError{op, field + " Hours", "<=", "0", 0, ls.Hours}
+		}
+		c := cc
+		if ls.Conditions != nil {
+			x, err := cc.WithConditions(*ls.Conditions)
+			if err != nil {
+				return nil, &OpError{op + " " + field, err}
+			}
+			c = x
+		}
+		tc, err := c.Tc(ls.Ta, ls.Ic)
+		if err != nil {
+			return nil, &OpError{op + " " + field, err}
+		}
+		r, _ := c.Resistance(tc) // Tc returns values in range
+		i2 := ls.Ic * ls.Ic * float64(n)
+		loss := i2 * r / 1000
+		lr.Steps[i] = LossStep{tc, r, loss}
+		lr.Hours += ls.Hours
+		lr.Energy += loss * ls.Hours
+		lr.EnergyR25 += i2 * r25 / 1000 * ls.Hours
+		if ls.Ic > peak {
+			peak = ls.Ic
+			lr.PeakLoss = loss
+		}
+	}
+	lr.MeanLoss = lr.Energy / lr.Hours
+	if lr.PeakLoss > 0 {
+		lr.LossFactor = lr.MeanLoss / lr.PeakLoss
+	}
+	return lr, nil
+}
+
+// Losses Returns the Joule losses of a phase with Nsc subconductors over a load profile.
+// Ic of every step is the current of a subconductor. See CurrentCalc.Losses.
+func (oi *OperatingItem) Losses(profile []LoadStep) (*LossResult, error) {
+	return oi.currentCalc.losses("OperatingItem.Losses", profile, oi.nsc)
+}
+
+// phaseProfile Returns a copy of profile with phase currents divided among nsc
+// subconductors
+func phaseProfile(profile []LoadStep, nsc int) []LoadStep {
+	p := make([]LoadStep, len(profile))
+	copy(p, profile)
+	for i := range p {
+		p[i].Ic /= float64(nsc)
+	}
+	return p
+}
+
+// LoadFactor Returns the mean current / highest current of a load profile weighted by
+// Hours (0 for an empty profile or without load)
+func LoadFactor(profile []LoadStep) float64 {
+	var hours, sum, peak float64
+	for _, ls := range profile {
+		hours += ls.Hours
+		sum += ls.Ic * ls.Hours
+		peak = math.Max(peak, ls.Ic)
+	}
+	if peak == 0 {
+		return 0
+	}
+	return sum / hours / peak
+}
@@ -0,0 +1,121 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getLoadDuration() []LoadStep {
+	return []LoadStep{{1000, 800, 30, nil}, {3760, 500, 25, nil}, {4000, 250, 15, nil}}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Losses_CurrentCalc(t *testing.T) {
+	cc := getCurrentCalc()
+	profile := getLoadDuration()
+	lr, err := cc.Losses(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lr.Hours != 8760 {
+		t.Errorf("8760 h expected got %f", lr.Hours)
+	}
+	sum := 0.0
+	for i, ls := range lr.Steps {
+		tc, _ := cc.Tc(profile[i].Ta, profile[i].Ic)
+		r, _ := cc.Resistance(tc)
+		if ls.Tc != tc || ls.R != r {
+			t.Errorf("Step %d: Tc %f R %f expected got %+v", i, tc, r, ls)
+		}
+		sum += profile[i].Ic * profile[i].Ic * r / 1000 * profile[i].Hours
+	}
+	if math.Abs(lr.Energy-sum) > 1e-6 {
+		t.Errorf("%f expected got %f", sum, lr.Energy)
+	}
+	if lr.Energy <= lr.EnergyR25 {
+		t.Errorf("Energy with R(Tc) > R25 expected got %f <= %f", lr.Energy, lr.EnergyR25)
+	}
+	if lr.PeakLoss != lr.Steps[0].Loss {
+		t.Errorf("Peak loss of the first step expected got %f", lr.PeakLoss)
+	}
+	if lr.LossFactor <= 0 || lr.LossFactor >= LoadFactor(profile) {
+		t.Errorf("0 < LossFactor < LoadFactor expected got %f", lr.LossFactor)
+	}
+
+	// Windier weather cools the conductor and lowers losses
+	windy := cc.Conditions()
+	windy.AirVelocity = 10
+	for i := range profile {
+		profile[i].Conditions = &windy
+	}
+	lw, _ := cc.Losses(profile)
+	if lw.Energy >= lr.Energy || lw.EnergyR25 != lr.EnergyR25 {
+		t.Errorf("Lower losses expected got %f >= %f", lw.Energy, lr.Energy)
+	}
+}
+
+func Test_Losses_OperatingItem(t *testing.T) {
+	cc := getCurrentCalc()
+	oi, _ := NewOperatingItem(cc, 75, 2)
+	profile := getLoadDuration()
+	lc, _ := cc.Losses(profile)
+	lp, err := oi.Losses(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(lp.Energy-2*lc.Energy) > 1e-6 || lp.LossFactor != lc.LossFactor {
+		t.Errorf("Twice the energy of a subconductor expected got %f and %f", lp.Energy,
+			lc.Energy)
+	}
+}
+
+func Test_Losses_Hourly(t *testing.T) {
+	profile, err := HourlyProfile([]float64{100, 200, 300}, []float64{20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profile) != 3 || profile[2].Ta != 20 || profile[2].Hours != 1 {
+		t.Errorf("Hourly steps expected got %+v", profile)
+	}
+	if lf := LoadFactor(profile); math.Abs(lf-2.0/3) > 1e-12 {
+		t.Errorf("%f expected got %f", 2.0/3, lf)
+	}
+	if _, err := HourlyProfile([]float64{100, 200}, []float64{20, 25, 30}); !errors.Is(err,
+		ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+}
+
+func Test_Losses_Errors(t *testing.T) {
+	cc := getCurrentCalc()
+	if _, err := cc.Losses(nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	for _, ls := range []LoadStep{{0, 100, 25, nil}, {1, -1, 25, nil}, {1, 100, TA_MAX + 1, nil},
+		{1, 100, 25, &Conditions{-1, 2, 90, 1, 0.5}}} {
+		if _, err := cc.Losses([]LoadStep{ls}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%+v: range error expected got %v", ls, err)
+		}
+	}
+}
+
+func Example_CurrentCalc_Losses() {
+	cc := getCurrentCalc()
+	lr, _ := cc.Losses(getLoadDuration())
+	fmt.Printf("Energy %.0f kWh/km (R25 %.0f kWh/km)\n", lr.Energy, lr.EnergyR25)
+	fmt.Printf("Loss factor %.3f\n", lr.LossFactor)
+	for _, ls := range lr.Steps {
+		fmt.Printf("%5.1f °C %6.2f kW/km\n", ls.Tc, ls.Loss)
+	}
+	// Output:
+	// Energy 181323 kWh/km (R25 163529 kWh/km)
+	// Loss factor 0.304
+	//  80.7 °C  68.02 kW/km
+	//  48.8 °C  24.15 kW/km
+	//  27.1 °C   5.62 kW/km
+}
@@ -0,0 +1,213 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Material Physical properties of a wire material
+type Material struct {
+	Name             string  // Name of material
+	Density          float64 // Density [kg/dm3]
+	SpecificHeat     float64 // Specific heat [J/(kg*°C)]
+	Resistivity      float64 // Resistivity at 20°C [Ohm*mm2/m] (0 for non conducting materials)
+	Alpha            float64 // Temperature coefficient of resistance at 20°C [1/°C]
+	Modelas          float64 // Modulus of elasticity [kg/mm2]
+	Coefexp          float64 // Coefficient of Thermal Expansion [1/°C]
+	Strength         float64 // Tensile strength of wires [kg/mm2]
+	TempMaxOp        float64 // Maximum continuous operating temperature [°C]
+	TempMaxEmergency float64 // Maximum emergency temperature [°C]
+	Core             bool    // Core material of composite conductors
+	Id               string  // Database id
+}
+
+// conductance Returns the conductance of a wire of area [mm2] and length factor k at
+// temperature t [°C] per km [S*km]
+func (m *Material) conductance(area float64, k float64, t float64) float64 {
+	if m.Resistivity == 0 {
+		return 0
+	}
+	return area / (m.Resistivity * (1 + m.Alpha*(t-20)) * k * 1000)
+}
+
+//----------------------------------------------------------------------------------------
+// *Material instances to use as constants
+//	MT_AL1350       : Hard drawn EC aluminium 1350-H19, 61.0% IACS
+//	MT_AL6201       : Aluminium alloy 6201-T81, 52.5% IACS
+//	MT_STEEL_GA     : Galvanised steel core wire (regular strength), 8% IACS
+//	MT_STEEL_AW     : Aluminium-clad steel core wire (20SA), 20.3% IACS
+//	MT_CU           : Hard drawn copper, 97% IACS
+//	MT_INVAR        : Invar (Fe-36Ni) core wire without cladding, 2.2% IACS
+//	MT_CARBON       : Carbon fibre composite core (non conducting)
+
+var (
+	MT_AL1350 = &Material{"EC ALUMINIUM 1350", 2.703, 897, 0.028264, 0.00403, 7000, 0.0000230,
+		17.0, 100, 125, false, "AL1350"}
+	MT_AL6201 = &Material{"ALUMINIUM ALLOY 6201", 2.690, 897, 0.032840, 0.00347, 7000,
+		0.0000230, 31.5, 90, 120, false, "AL6201"}
+	MT_STEEL_GA = &Material{"GALVANISED STEEL", 7.780, 481, 0.215500, 0.00450, 20400,
+		0.0000115, 131.0, 200, 250, true, "STEEL_GA"}
+	MT_STEEL_AW = &Material{"ALUMINIUM-CLAD STEEL", 6.590, 518, 0.084850, 0.00360, 16500,
+		0.0000130, 137.0, 200, 250, true, "STEEL_AW"}
+	MT_CU = &Material{"COPPER", 8.890, 385, 0.017774, 0.00381, 12000, 0.0000169, 42.0, 75,
+		100, false, "CU"}
+	MT_INVAR = &Material{"INVAR", 8.110, 515, 0.800000, 0.00200, 15500, 0.0000037, 110.0, 210,
+		240, true, "INVAR"}
+	MT_CARBON = &Material{"CARBON COMPOSITE", 1.830, 1000, 0, 0, 11500, 0.0000016, 220.0, 180,
+		200, true, "CARBON"}
+)
+
+// MATERIALS Materials by id
+var MATERIALS = map[string]*Material{
+	MT_AL1350.Id:   MT_AL1350,
+	MT_AL6201.Id:   MT_AL6201,
+	MT_STEEL_GA.Id: MT_STEEL_GA,
+	MT_STEEL_AW.Id: MT_STEEL_AW,
+	MT_CU.Id:       MT_CU,
+	MT_INVAR.Id:    MT_INVAR,
+	MT_CARBON.Id:   MT_CARBON,
+}
+
+//----------------------------------------------------------------------------------------
+
+// MaterialPart Fraction of the cross section area of a conductor made of a Material
+type MaterialPart struct {
+	Material *Material // *Material instance
+	Fraction float64   // Fraction of the cross section area (0 to 1)
+}
+
+// NewComposition Returns *Composition object
+// parts []MaterialPart : Materials with area fractions > 0 that add to 1 (one conducting)
+func NewComposition(parts []MaterialPart) (*Composition, error) {
+	if len(parts) < 1 {
+		return nil, &ConfigError{"NewComposition", "len(parts)", "< 1"}
+	}
+	total := 0.0
+	conducting := false
+	for i, p := range parts {
+		field := "part " + strconv.Itoa(i)
+		if p.Material == nil {
+			return nil, &ConfigError{"NewComposition", field + " Material", "== nil"}
+		}
+		if p.Fraction <= 0 {
+			return nil, &RangeError{"NewComposition", field + " Fraction", "<=", "0", 0,
+				p.Fraction}
+		}
+		total += p.Fraction
+		conducting = conducting || p.Material.Resistivity > 0
+	}
+	if math.Abs(total-1) > 1e-6 {
+		return nil, &ConfigError{"NewComposition", "Fraction", "sum of parts must be 1"}
+	}
+	if !conducting {
+		return nil, &ConfigError{"NewComposition", "parts", "without conducting material"}
+	}
+	list := make([]MaterialPart, len(parts))
+	copy(list, parts)
+	return &Composition{list}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// Composition Materials of a conductor by area fraction. Computes Category properties,
+// heat capacity and temperature limits from the materials.
+type Composition struct {
+	parts []MaterialPart // Materials and area fractions
+}
+
+// Modelas Returns the modulus of elasticity weighted by area [kg/mm2]
+func (co *Composition) Modelas() float64 {
+	e := 0.0
+	for _, p := range co.parts {
+		e += p.Fraction * p.Material.Modelas
+	}
+	return e
+}
+
+// Coefexp Returns the coefficient of thermal expansion weighted by area and modulus of
+// elasticity [1/°C]
+func (co *Composition) Coefexp() float64 {
+	ea := 0.0
+	for _, p := range co.parts {
+		ea += p.Fraction * p.Material.Modelas * p.Material.Coefexp
+	}
+	return ea / co.Modelas()
+}
+
+// Alpha Returns the temperature coefficient of resistance between 25°C and 75°C of the
+// materials in parallel [1/°C]
+func (co *Composition) Alpha() float64 {
+	r25 := co.Resistance(1, 25)
+	return (co.Resistance(1, 75) - r25) / (50 * r25)
+}
+
+// Resistance Returns the DC resistance of a straight conductor [Ohm/km]
+// area float64 : Cross section area [mm2]
+// t    float64 : Conductor temperature [°C]
+func (co *Composition) Resistance(area float64, t float64) float64 {
+	g := 0.0
+	for _, p := range co.parts {
+		g += p.Material.conductance(p.Fraction*area, 1, t)
+	}
+	return 1 / g
+}
+
+// Density Returns the density weighted by area [kg/dm3]
+func (co *Composition) Density() float64 {
+	d := 0.0
+	for _, p := range co.parts {
+		d += p.Fraction * p.Material.Density
+	}
+	return d
+}
+
+// Hcap Returns the heat capacity of a conductor [kcal/(ft*°C)]
+// area float64 : Cross section area [mm2]
+func (co *Composition) Hcap(area float64) float64 {
+	total := 0.0 // [J/(m*°C)]
+	for _, p := range co.parts {
+		total += p.Fraction * area * p.Material.Density / 1000 * p.Material.SpecificHeat
+	}
+	return total * foot / 1000 / kcal
+}
+
+// TempMaxOp Returns the maximum continuous operating temperature, the lowest of the
+// materials [°C]
+func (co *Composition) TempMaxOp() float64 {
+	t := math.Inf(1)
+	for _, p := range co.parts {
+		t = math.Min(t, p.Material.TempMaxOp)
+	}
+	return t
+}
+
+// TempMaxEmergency Returns the maximum emergency temperature, the lowest of the
+// materials [°C]
+func (co *Composition) TempMaxEmergency() float64 {
+	t := math.Inf(1)
+	for _, p := range co.parts {
+		t = math.Min(t, p.Material.TempMaxEmergency)
+	}
+	return t
+}
+
+// Category Returns *Category with the properties of the materials
+// name  string  : Name of conductor category
+// creep float64 : Creep °C
+// id    string  : Database id
+func (co *Composition) Category(name string, creep float64, id string) *Category {
+	return &Category{name, co.Modelas(), co.Coefexp(), creep, co.Alpha(), id}
+}
+
+// Part Returns part i
+func (co *Composition) Part(i int) MaterialPart {
+	return co.parts[i]
+}
+
+func (co *Composition) Len() int {
+	return len(co.parts)
+}
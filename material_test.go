@@ -0,0 +1,97 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func Test_Material_Database(t *testing.T) {
+	if len(MATERIALS) != 7 {
+		t.Errorf("7 materials expected got %d", len(MATERIALS))
+	}
+	for id, m := range MATERIALS {
+		if m.Id != id || m.Density <= 0 || m.SpecificHeat <= 0 || m.Modelas <= 0 ||
+			m.Coefexp <= 0 || m.TempMaxOp <= 0 || m.TempMaxEmergency < m.TempMaxOp {
+			t.Errorf("%s: invalid properties %+v", id, m)
+		}
+	}
+}
+
+func Test_Material_Composition(t *testing.T) {
+	// Single material reproduces the material
+	co, err := NewComposition([]MaterialPart{{MT_CU, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cat := co.Category("COPPER", 0, "CU")
+	if cat.Modelas() != MT_CU.Modelas || math.Abs(cat.Coefexp()-MT_CU.Coefexp) > 1e-12 {
+		t.Errorf("Copper properties expected got %+v", cat)
+	}
+	if want := MT_CU.Alpha / (1 + 5*MT_CU.Alpha); math.Abs(cat.Alpha()-want) > 1e-9 {
+		t.Errorf("Alpha %f expected got %f", want, cat.Alpha())
+	}
+	if co.TempMaxOp() != 75 || co.TempMaxEmergency() != 100 {
+		t.Errorf("75/100 expected got %f/%f", co.TempMaxOp(), co.TempMaxEmergency())
+	}
+
+	// Carbon core does not conduct and sets no limit below aluminium
+	acc, err := NewComposition([]MaterialPart{{MT_AL1350, 0.85}, {MT_CARBON, 0.15}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := acc.Resistance(100, 20); math.Abs(r-MT_AL1350.Resistivity*1000/85) > 1e-9 {
+		t.Errorf("Aluminium resistance expected got %f", r)
+	}
+	if acc.TempMaxOp() != MT_AL1350.TempMaxOp {
+		t.Errorf("%f expected got %f", MT_AL1350.TempMaxOp, acc.TempMaxOp())
+	}
+}
+
+func Test_Material_CompositionErrors(t *testing.T) {
+	tests := [][]MaterialPart{
+		nil,
+		{{nil, 1}},
+		{{MT_AL1350, 0}, {MT_STEEL_GA, 1}},
+		{{MT_AL1350, 0.5}, {MT_STEEL_GA, 0.4}},
+		{{MT_CARBON, 1}},
+	}
+	for i, parts := range tests {
+		if _, err := NewComposition(parts); !errors.Is(err, ErrInvalidConfig) &&
+			!errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%d: error expected got %v", i, err)
+		}
+	}
+}
+
+func Test_Material_Stranding(t *testing.T) {
+	s := getStranding()
+	co, err := s.Composition()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if co.Len() != 2 || math.Abs(co.Part(0).Fraction*s.Area()-s.SteelArea()) > 1e-9 {
+		t.Errorf("Steel and aluminium parts expected got %+v", co)
+	}
+	if math.Abs(co.Modelas()-s.Modelas()) > 1e-9 || math.Abs(co.Coefexp()-s.Coefexp()) > 1e-15 {
+		t.Errorf("Same properties expected got %f %g", co.Modelas(), co.Coefexp())
+	}
+	if co.TempMaxOp() != 100 {
+		t.Errorf("100 expected got %f", co.TempMaxOp())
+	}
+}
+
+func Example_Composition() {
+	co, _ := NewComposition([]MaterialPart{{MT_AL1350, 0.86}, {MT_STEEL_GA, 0.14}})
+	cat := co.Category("ACSR", 20, "ACSR")
+	fmt.Printf("%s %.0f kg/mm2 %.2e 1/°C %.5f 1/°C\n", cat.Name(), cat.Modelas(),
+		cat.Coefexp(), cat.Alpha())
+	fmt.Printf("%.4f kcal/(ft*°C) %.0f/%.0f °C\n", co.Hcap(468.5), co.TempMaxOp(),
+		co.TempMaxEmergency())
+	// Output:
+	// ACSR 8876 kg/mm2 1.93e-05 1/°C 0.00396 1/°C
+	// 0.0890 kcal/(ft*°C) 100/125 °C
+}
@@ -0,0 +1,96 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math/cmplx"
+)
+
+//----------------------------------------------------------------------------------------
+
+// cmatrix Square matrix of complex numbers by rows
+type cmatrix [][]complex128
+
+// newCMatrix Returns a n x n zero matrix
+func newCMatrix(n int) cmatrix {
+	m := make(cmatrix, n)
+	for i := range m {
+		m[i] = make([]complex128, n)
+	}
+	return m
+}
+
+// inverse Returns the inverse of m with Gauss-Jordan elimination and partial pivoting.
+// Returns false if m is singular.
+func (m cmatrix) inverse() (cmatrix, bool) {
+	n := len(m)
+	a := newCMatrix(n)
+	inv := newCMatrix(n)
+	for i := range m {
+		copy(a[i], m[i])
+		inv[i][i] = 1
+	}
+	for col := 0; col < n; col++ {
+		p := col
+		for i := col + 1; i < n; i++ {
+			if cmplx.Abs(a[i][col]) > cmplx.Abs(a[p][col]) {
+				p = i
+			}
+		}
+		if a[p][col] == 0 {
+			return nil, false
+		}
+		a[col], a[p] = a[p], a[col]
+		inv[col], inv[p] = inv[p], inv[col]
+		d := a[col][col]
+		for j := 0; j < n; j++ {
+			a[col][j] /= d
+			inv[col][j] /= d
+		}
+		for i := 0; i < n; i++ {
+			if i == col || a[i][col] == 0 {
+				continue
+			}
+			f := a[i][col]
+			for j := 0; j < n; j++ {
+				a[i][j] -= f * a[col][j]
+				inv[i][j] -= f * inv[col][j]
+			}
+		}
+	}
+	return inv, true
+}
+
+// kron Returns the first n rows and columns of m after eliminating the rest (grounded
+// conductors) with Kron reduction: Mr = Maa - Mab·Mbb⁻¹·Mba. Returns false if Mbb is
+// singular.
+func (m cmatrix) kron(n int) (cmatrix, bool) {
+	k := len(m) - n
+	r := newCMatrix(n)
+	for i := 0; i < n; i++ {
+		copy(r[i], m[i][:n])
+	}
+	if k == 0 {
+		return r, true
+	}
+	mbb := newCMatrix(k)
+	for i := 0; i < k; i++ {
+		copy(mbb[i], m[n+i][n:])
+	}
+	inv, ok := mbb.inverse()
+	if !ok {
+		return nil, false
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var s complex128
+			for p := 0; p < k; p++ {
+				for q := 0; q < k; q++ {
+					s += m[i][n+p] * inv[p][q] * m[n+q][j]
+				}
+			}
+			r[i][j] -= s
+		}
+	}
+	return r, true
+}
@@ -15,16 +15,16 @@ import (
 func NewOperatingItem(currentCalc *CurrentCalc, tempMaxOp float64,
 	nsc int) (*OperatingItem, error) {
 	if currentCalc == nil {
-		return nil, &ValueError{"NewCurrentCalc: currentCalc == nil"}
+		return nil, &ConfigError{"NewOperatingItem", "currentCalc", "== nil"}
 	}
 	if tempMaxOp < TC_MIN {
-		return nil, &ValueError{"NewOperatingItem: tempMaxOp < TC_MIN"}
+		return nil, &RangeError{"NewOperatingItem", "tempMaxOp", "<", "TC_MIN", TC_MIN, tempMaxOp}
 	}
 	if tempMaxOp > TC_MAX {
-		return nil, &ValueError{"NewOperatingItem: tempMaxOp > TC_MAX"}
+		return nil, &RangeError{"NewOperatingItem", "tempMaxOp", ">", "TC_MAX", TC_MAX, tempMaxOp}
 	}
 	if nsc < 1 {
-		return nil, &ValueError{"NewOperatingItem: nsc < 1"}
+		return nil, &RangeError{"NewOperatingItem", "nsc", "<", "1", 1, float64(nsc)}
 	}
 	return &OperatingItem{currentCalc, tempMaxOp, nsc}, nil
 }
@@ -41,10 +41,10 @@ type OperatingItem struct {
 
 func (oi *OperatingItem) Current(ta float64) (float64, error) {
 	if ta < TA_MIN {
-		return math.NaN(), &ValueError{"OperatingItem.Current: ta < TA_MIN"}
+		return math.NaN(), &RangeError{"OperatingItem.Current", "ta", "<", "TA_MIN", TA_MIN, ta}
 	}
 	if ta > TA_MAX {
-		return math.NaN(), &ValueError{"OperatingItem.Current: ta > TA_MAX"}
+		return math.NaN(), &RangeError{"OperatingItem.Current", "ta", ">", "TA_MAX", TA_MAX, ta}
 	}
 	return oi.currentCalc.Current(ta, oi.tempMaxOp)
 }
@@ -68,14 +68,14 @@ func (oi *OperatingItem) Nsc() int {
 //	id    string           : Database id
 func NewOperatingTable(items []*OperatingItem, id string) (*OperatingTable, error) {
 	if items == nil {
-		return nil, &ValueError{"NewOperatingTable: items == nil"}
+		return nil, &ConfigError{"NewOperatingTable", "items", "== nil"}
 	}
 	if len(items) < 1 {
-		return nil, &ValueError{"NewOperatingTable: len(items) < 1"}
+		return nil, &ConfigError{"NewOperatingTable", "len(items)", "< 1"}
 	}
 	for _, i := range items {
 		if i == nil {
-			return nil, &ValueError{"NewOperatingTable: item == nil"}
+			return nil, &ConfigError{"NewOperatingTable", "item", "== nil"}
 		}
 	}
 	return &OperatingTable{items, id}, nil
@@ -92,10 +92,10 @@ type OperatingTable struct {
 
 func (ot *OperatingTable) Current(ta float64) (float64, error) {
 	if ta < TA_MIN {
-		return math.NaN(), &ValueError{"OperatingTable.Current: ta < TA_MIN"}
+		return math.NaN(), &RangeError{"OperatingTable.Current", "ta", "<", "TA_MIN", TA_MIN, ta}
 	}
 	if ta > TA_MAX {
-		return math.NaN(), &ValueError{"OperatingTable.Current: ta > TA_MAX"}
+		return math.NaN(), &RangeError{"OperatingTable.Current", "ta", ">", "TA_MAX", TA_MAX, ta}
 	}
 	cur, _ := ot.items[0].Current(ta)
 	for _, x := range ot.items[1:] {
@@ -108,7 +108,7 @@ func (ot *OperatingTable) Current(ta float64) (float64, error) {
 
 func (ot *OperatingTable) Append(item *OperatingItem) error {
 	if item == nil {
-		return &ValueError{"OperatingTable.Append: items == nil"}
+		return &ConfigError{"OperatingTable.Append", "item", "== nil"}
 	}
 	ot.items = append(ot.items, item)
 	return nil
@@ -117,3 +117,14 @@ func (ot *OperatingTable) Append(item *OperatingItem) error {
 func (ot *OperatingTable) Len() int {
 	return len(ot.items)
 }
+
+// Items Returns a copy of the slice of *OperatingItem
+func (ot *OperatingTable) Items() []*OperatingItem {
+	items := make([]*OperatingItem, len(ot.items))
+	copy(items, ot.items)
+	return items
+}
+
+func (ot *OperatingTable) Id() string {
+	return ot.id
+}
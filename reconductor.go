@@ -0,0 +1,172 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// ReconductorCandidate Replacement conductor for Reconductor
+type ReconductorCandidate struct {
+	Conductor     *Conductor // *Conductor of subconductors (weight, area, strength > 0)
+	TempMaxOp     float64    // Maximum operating temperature [°C]
+	TempEmergency float64    // Emergency temperature [°C] (required >= TempMaxOp)
+}
+
+// ReconductorStudy Span, structure and load data shared by the existing conductor and the
+// candidates of Reconductor. Candidates are strung at TString with a common %RTS, by
+// default the one of the existing conductor; tensions at the Design load come from the
+// change of state.
+type ReconductorStudy struct {
+	Span          float64    // Ruling span [m] (required > 0)
+	TString       float64    // Conductor temperature of the stringing state [°C]
+	HString       float64    // Horizontal tension of an existing subconductor at TString [kg]
+	RTSString     float64    // Stringing tension / rated strength of candidates (0 existing)
+	Design        LoadCase   // Design load of the structures (ice and wind)
+	RTSMax        float64    // Maximum design Tension / rated strength (0 uses 0.6)
+	TempEmergency float64    // Emergency temperature of the existing conductor [°C]
+	Ta            float64    // Ambient temperature for ratings [°C]
+	Profile       []LoadStep // Load profile, Ic is the phase current [A] (nil skips losses)
+}
+
+// ReconductorOption Results of the existing conductor or a candidate. Tensions and sags
+// are of a subconductor; ratings and losses are of a phase.
+type ReconductorOption struct {
+	Conductor     *Conductor  // *Conductor of subconductors
+	Rating        float64     // Steady state rating at TempMaxOp [A]
+	Emergency     float64     // Emergency rating at TempEmergency [A]
+	StringTension float64     // Horizontal tension at TString [kg]
+	Tension       float64     // Horizontal tension at the Design load [kg]
+	TensionRatio  float64     // Tension / existing Tension
+	RTS           float64     // Tension / rated strength
+	Sag           float64     // Sag at TempEmergency, the maximum temperature [m]
+	SagDelta      float64     // Sag - existing Sag [m] (> 0 reduces clearances)
+	Losses        *LossResult // Losses of a phase over Profile (nil without Profile or on Err)
+	Feasible      bool        // Err == nil, RTS <= RTSMax, SagDelta <= 0, TensionRatio <= 1
+	Err           error       // Error calculating losses
+}
+
+// ReconductorResult Comparison of candidates with the existing conductor
+type ReconductorResult struct {
+	Existing   ReconductorOption   // Existing conductor
+	Candidates []ReconductorOption // Candidates in the order of the arguments
+}
+
+//----------------------------------------------------------------------------------------
+
+// Reconductor Compares candidate conductors for the existing phases of OperatingItem
+// existing, with the same number of subconductors, weather and heat balance model.
+// Candidates are strung at TString with RTSString of their rated strength. Tensions at
+// the Design load and sags at the emergency temperature come from SagTensionCalc with the
+// stringing state as reference. A candidate is feasible if it does not increase structure
+// loads (TensionRatio <= 1) nor sag, and its design tension is within RTSMax.
+func Reconductor(existing *OperatingItem, candidates []ReconductorCandidate,
+	study ReconductorStudy) (*ReconductorResult, error) {
+	op := "Reconductor"
+	if existing == nil {
+		return nil, &ConfigError{op, "existing", "== nil"}
+	}
+	if study.Span <= 0 {
+		return nil, &RangeError{op, "Span", "<=", "0", 0, study.Span}
+	}
+	if study.HString <= 0 {
+		return nil, &RangeError{op, "HString", "<=", "0", 0, study.HString}
+	}
+	if err := study.Design.check(op + " Design"); err != nil {
+		return nil, err
+	}
+	if study.RTSString < 0 {
+		return nil, &RangeError{op, "RTSString", "<", "0", 0, study.RTSString}
+	}
+	if study.RTSMax < 0 {
+		return nil, &RangeError{op, "RTSMax", "<", "0", 0, study.RTSMax}
+	}
+	if study.TempEmergency < existing.tempMaxOp {
+		return nil, &RangeError{op, "TempEmergency", "<", "TempMaxOp", existing.tempMaxOp,
+			study.TempEmergency}
+	}
+	cc := existing.currentCalc
+	if cc.conductor.strength <= 0 {
+		return nil, &RangeError{op, "existing Conductor.Strength", "<=", "0", 0,
+			cc.conductor.strength}
+	}
+	rts := orDefault(study.RTSString, study.HString/cc.conductor.strength)
+	rtsMax := orDefault(study.RTSMax, 0.6)
+	base, err := reconductorOption(op, existing, study.TempEmergency, study.HString, study)
+	if err != nil {
+		return nil, err
+	}
+	rr := &ReconductorResult{Existing: *base, Candidates: make([]ReconductorOption,
+		len(candidates))}
+	rr.Existing.TensionRatio = 1
+	rr.Existing.Feasible = rr.Existing.Err == nil && rr.Existing.RTS <= rtsMax
+	for i, cand := range candidates {
+		field := "candidate " + strconv.Itoa(i)
+		if cand.Conductor == nil {
+			return nil, &ConfigError{op, field + " Conductor", "== nil"}
+		}
+		if cand.Conductor.strength <= 0 {
+			return nil, &RangeError{op, field + " Conductor.Strength", "<=", "0", 0,
+				cand.Conductor.strength}
+		}
+		if cand.TempEmergency < cand.TempMaxOp {
+			return nil, &RangeError{op, field + " TempEmergency", "<", "TempMaxOp",
+				cand.TempMaxOp, cand.TempEmergency}
+		}
+		if _, err := NewCurrentCalc(cand.Conductor); err != nil {
+			return nil, &OpError{op + " " + field, err}
+		}
+		c := *cc // Same conditions and model
+		c.conductor = cand.Conductor
+		oi, err := NewOperatingItem(&c, cand.TempMaxOp, existing.nsc)
+		if err != nil {
+			return nil, &OpError{op + " " + field, err}
+		}
+		ro, err := reconductorOption(op+" "+field, oi, cand.TempEmergency,
+			rts*cand.Conductor.strength, study)
+		if err != nil {
+			return nil, err
+		}
+		ro.TensionRatio = ro.Tension / rr.Existing.Tension
+		ro.SagDelta = ro.Sag - rr.Existing.Sag
+		ro.Feasible = ro.Err == nil && ro.RTS <= rtsMax && ro.SagDelta <= 0 &&
+			ro.TensionRatio <= 1
+		rr.Candidates[i] = *ro
+	}
+	return rr, nil
+}
+
+// reconductorOption Returns ratings, tensions, sag and losses of OperatingItem oi with
+// emergency temperature te and horizontal tension h at the stringing state
+func reconductorOption(op string, oi *OperatingItem, te float64, h float64,
+	study ReconductorStudy) (*ReconductorOption, error) {
+	c := oi.currentCalc.conductor
+	nsc := float64(oi.nsc)
+	ro := &ReconductorOption{Conductor: c, StringTension: h}
+	i, err := oi.Current(study.Ta)
+	if err != nil {
+		return nil, &OpError{op, err}
+	}
+	ie, err := oi.currentCalc.Current(study.Ta, te)
+	if err != nil {
+		return nil, &OpError{op, err}
+	}
+	ro.Rating, ro.Emergency = i*nsc, ie*nsc
+	st, err := NewSagTensionCalc(c, study.Span, study.TString, h)
+	if err != nil {
+		return nil, &OpError{op, err}
+	}
+	if ro.Tension, _, err = st.LoadCaseTension(study.Design); err != nil {
+		return nil, &OpError{op, err}
+	}
+	ro.RTS = ro.Tension / c.strength
+	if ro.Sag, err = st.Sag(te); err != nil {
+		return nil, &OpError{op, err}
+	}
+	if study.Profile != nil {
+		ro.Losses, ro.Err = oi.Losses(phaseProfile(study.Profile, oi.nsc))
+	}
+	return ro, nil
+}
@@ -0,0 +1,177 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getReconductorExisting() *OperatingItem {
+	cmk := ConductorMaker{"ACSR 795 MCM DRAKE", CC_ACSR, 28.14, 468.5, 1.628, 14290, 0.0718, 0,
+		""}
+	cc, _ := NewCurrentCalc(cmk.Get())
+	oi, _ := NewOperatingItem(cc, 75, 1)
+	return oi
+}
+
+func getReconductorCandidates() []ReconductorCandidate {
+	htls := &CategoryMaker{"ACCC", 6200, 0.0000016, 0, 0.00403, "ACCC"}
+	makers := []ConductorMaker{
+		{"ACSR 1272 MCM BITTERN", CC_ACSR, 34.16, 726.8, 2.134, 15600, 0.0454, 0, ""},
+		{"ACCC 1020 DRAKE", htls.Get(), 28.14, 549.0, 1.562, 18600, 0.0547, 0, ""},
+	}
+	return []ReconductorCandidate{{makers[0].Get(), 75, 100}, {makers[1].Get(), 180, 200}}
+}
+
+func getReconductorStudy() ReconductorStudy {
+	profile := []LoadStep{{2000, 700, 30, nil}, {6760, 400, 20, nil}}
+	return ReconductorStudy{350, 15, 2850, 0.15, NESC_HEAVY, 0, 100, 30, profile}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Reconductor_Compare(t *testing.T) {
+	existing := getReconductorExisting()
+	study := getReconductorStudy()
+	rr, err := Reconductor(existing, getReconductorCandidates(), study)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := rr.Existing
+	rating, _ := existing.Current(study.Ta)
+	if ex.Rating != rating || ex.StringTension != study.HString || ex.TensionRatio != 1 ||
+		!ex.Feasible {
+		t.Errorf("Existing rating %f and tension %f expected got %+v", rating, study.HString, ex)
+	}
+	if len(rr.Candidates) != 2 {
+		t.Fatalf("2 candidates expected got %d", len(rr.Candidates))
+	}
+	for _, ro := range append([]ReconductorOption{ex}, rr.Candidates...) {
+		c := ro.Conductor
+		st, _ := NewSagTensionCalc(c, study.Span, study.TString, ro.StringTension)
+		h, _, _ := st.LoadCaseTension(study.Design)
+		if ro.Tension != h || ro.Tension <= ro.StringTension || ro.RTS != h/c.Strength() {
+			t.Errorf("%s: design tension %f expected got %+v", c.Name(), h, ro)
+		}
+		if math.Abs(ro.TensionRatio-ro.Tension/ex.Tension) > 1e-12 {
+			t.Errorf("%s: wrong tension ratio %f", c.Name(), ro.TensionRatio)
+		}
+		if ro.Emergency <= ro.Rating || ro.Losses == nil || ro.Err != nil {
+			t.Errorf("%s: wrong result %+v", c.Name(), ro)
+		}
+		if math.Abs(ro.SagDelta-(ro.Sag-ex.Sag)) > 1e-12 {
+			t.Errorf("%s: wrong sag delta %f", c.Name(), ro.SagDelta)
+		}
+	}
+	for _, ro := range rr.Candidates {
+		if math.Abs(ro.StringTension/ro.Conductor.Strength()-study.RTSString) > 1e-12 {
+			t.Errorf("%s: strung at RTSString expected got %+v", ro.Conductor.Name(), ro)
+		}
+	}
+
+	// The larger ACSR sags more; the HTLS raises the rating within the existing sag and
+	// structure loads
+	bittern, accc := rr.Candidates[0], rr.Candidates[1]
+	if bittern.Feasible || bittern.SagDelta <= 0 {
+		t.Errorf("BITTERN not feasible expected got %+v", bittern)
+	}
+	if !accc.Feasible || accc.TensionRatio > 1 || accc.Rating < 1.5*ex.Rating {
+		t.Errorf("ACCC feasible with 1.5 rating expected got %+v", accc)
+	}
+	if accc.Losses.Energy >= ex.Losses.Energy {
+		t.Errorf("Lower losses expected got %f >= %f", accc.Losses.Energy, ex.Losses.Energy)
+	}
+
+	// With the %RTS of the existing conductor the stronger HTLS overloads the structures
+	study.RTSString = 0
+	rr, _ = Reconductor(existing, getReconductorCandidates(), study)
+	accc = rr.Candidates[1]
+	rts := study.HString / ex.Conductor.Strength()
+	if math.Abs(accc.StringTension/accc.Conductor.Strength()-rts) > 1e-12 ||
+		accc.TensionRatio <= 1 || accc.Feasible {
+		t.Errorf("ACCC at %.3f RTS not feasible expected got %+v", rts, accc)
+	}
+
+	// Design tension limited by RTSMax
+	study.RTSMax = 0.4
+	if rr, _ = Reconductor(existing, nil, study); rr.Existing.Feasible {
+		t.Errorf("Existing not feasible with RTS %f expected", rr.Existing.RTS)
+	}
+}
+
+func Test_Reconductor_Nsc(t *testing.T) {
+	existing := getReconductorExisting()
+	twin, _ := NewOperatingItem(existing.CurrentCalc(), 75, 2)
+	study := getReconductorStudy()
+	r1, _ := Reconductor(existing, nil, study)
+	r2, _ := Reconductor(twin, nil, study)
+	if math.Abs(r2.Existing.Rating-2*r1.Existing.Rating) > 1e-9 || r2.Existing.Sag != r1.Existing.Sag {
+		t.Errorf("Twice the rating and same sag expected got %+v", r2.Existing)
+	}
+	if r2.Existing.Losses.Energy >= r1.Existing.Losses.Energy {
+		t.Errorf("Lower losses expected got %f", r2.Existing.Losses.Energy)
+	}
+}
+
+func Test_Reconductor_Errors(t *testing.T) {
+	existing := getReconductorExisting()
+	candidates := getReconductorCandidates()
+	study := getReconductorStudy()
+	if _, err := Reconductor(nil, candidates, study); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+	for _, s := range []ReconductorStudy{
+		{0, 15, 2850, 0, NESC_HEAVY, 0, 100, 30, nil},
+		{350, 15, 0, 0, NESC_HEAVY, 0, 100, 30, nil},
+		{350, 15, 2850, 0, NESC_HEAVY, 0, 70, 30, nil},
+		{350, 15, 2850, 0, NESC_HEAVY, 0, 100, TA_MAX + 1, nil},
+		{350, 15, 2850, -0.1, NESC_HEAVY, 0, 100, 30, nil},
+		{350, 15, 2850, 0, NESC_HEAVY, -0.1, 100, 30, nil},
+		{350, 15, 2850, 0, LoadCase{"X", 0, -1, 0, 0, 0}, 0, 100, 30, nil},
+	} {
+		if _, err := Reconductor(existing, candidates, s); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%+v: range error expected got %v", s, err)
+		}
+	}
+	candidates[1].TempEmergency = 150
+	if _, err := Reconductor(existing, candidates, study); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	candidates[1].Conductor = nil
+	if _, err := Reconductor(existing, candidates, study); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Config error expected got %v", err)
+	}
+
+	// Losses errors are reported by option and make it not feasible
+	study.Profile = []LoadStep{{0, 500, 30, nil}}
+	rr, err := Reconductor(existing, getReconductorCandidates(), study)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ro := range append([]ReconductorOption{rr.Existing}, rr.Candidates...) {
+		if !errors.Is(ro.Err, ErrOutOfRange) || ro.Losses != nil || ro.Feasible {
+			t.Errorf("%s: range error of losses expected got %+v", ro.Conductor.Name(), ro)
+		}
+	}
+}
+
+func Example_Reconductor() {
+	rr, _ := Reconductor(getReconductorExisting(), getReconductorCandidates(),
+		getReconductorStudy())
+	print := func(ro ReconductorOption) {
+		fmt.Printf("%-22s %4.0f A %4.0f A %5.0f kg %5.0f kg %.2f %5.2f m %+5.2f m "+
+			"%4.0f MWh/km %v\n", ro.Conductor.Name(), ro.Rating, ro.Emergency, ro.StringTension, ro.Tension,
+			ro.TensionRatio, ro.Sag, ro.SagDelta, ro.Losses.Energy/1000, ro.Feasible)
+	}
+	print(rr.Existing)
+	for _, ro := range rr.Candidates {
+		print(ro)
+	}
+	// Output:
+	// ACSR 795 MCM DRAKE      850 A 1074 A  2850 kg  6105 kg 1.00 11.91 m +0.00 m  162 MWh/km true
+	// ACSR 1272 MCM BITTERN  1125 A 1428 A  2340 kg  4991 kg 0.82 16.34 m +4.43 m  100 MWh/km false
+	// ACCC 1020 DRAKE        1747 A 1846 A  2790 kg  5438 kg 0.89  9.19 m -2.71 m  122 MWh/km true
+}
@@ -0,0 +1,222 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"sort"
+	"sync"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Repository Storage for categories, conductors and operating tables identified by Id.
+// Conductors must reference a stored Category and OperatingTables must reference stored
+// Conductors, so every tool loading the same repository shares the same objects.
+type Repository interface {
+	GetCategory(id string) (*Category, error)
+	ListCategories() ([]*Category, error)
+	PutCategory(cat *Category) error
+	DeleteCategory(id string) error
+
+	GetConductor(id string) (*Conductor, error)
+	ListConductors() ([]*Conductor, error)
+	PutConductor(c *Conductor) error
+	DeleteConductor(id string) error
+
+	GetOperatingTable(id string) (*OperatingTable, error)
+	ListOperatingTables() ([]*OperatingTable, error)
+	PutOperatingTable(ot *OperatingTable) error
+	DeleteOperatingTable(id string) error
+}
+
+//----------------------------------------------------------------------------------------
+
+// NewMemoryRepository Returns an empty *MemoryRepository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		categories: make(map[string]*Category),
+		conductors: make(map[string]*Conductor),
+		tables:     make(map[string]*OperatingTable),
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+// MemoryRepository Repository that keeps objects in memory. Safe for concurrent use.
+type MemoryRepository struct {
+	mu         sync.RWMutex
+	categories map[string]*Category       // *Category by id
+	conductors map[string]*Conductor      // *Conductor by id
+	tables     map[string]*OperatingTable // *OperatingTable by id
+}
+
+func (r *MemoryRepository) GetCategory(id string) (*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cat, ok := r.categories[id]
+	if !ok {
+		return nil, &NotFoundError{"MemoryRepository.GetCategory", "category", id}
+	}
+	return cat, nil
+}
+
+func (r *MemoryRepository) ListCategories() ([]*Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.categories))
+	for id := range r.categories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	list := make([]*Category, len(ids))
+	for i, id := range ids {
+		list[i] = r.categories[id]
+	}
+	return list, nil
+}
+
+// PutCategory Stores cat. Conductors are immutable, so replacing a category with the
+// same Id does not change stored conductors: they keep the previous *Category until they
+// are built with the new one and put again.
+func (r *MemoryRepository) PutCategory(cat *Category) error {
+	if cat == nil {
+		return &ConfigError{"MemoryRepository.PutCategory", "cat", "== nil"}
+	}
+	if cat.id == "" {
+		return &ConfigError{"MemoryRepository.PutCategory", "Category.Id", "is empty"}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.categories[cat.id] = cat
+	return nil
+}
+
+func (r *MemoryRepository) DeleteCategory(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.categories[id]; !ok {
+		return &NotFoundError{"MemoryRepository.DeleteCategory", "category", id}
+	}
+	for _, c := range r.conductors {
+		if c.category != nil && c.category.id == id {
+			return &ConfigError{"MemoryRepository.DeleteCategory", id, "used by conductor " + c.id}
+		}
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+func (r *MemoryRepository) GetConductor(id string) (*Conductor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.conductors[id]
+	if !ok {
+		return nil, &NotFoundError{"MemoryRepository.GetConductor", "conductor", id}
+	}
+	return c, nil
+}
+
+func (r *MemoryRepository) ListConductors() ([]*Conductor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.conductors))
+	for id := range r.conductors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	list := make([]*Conductor, len(ids))
+	for i, id := range ids {
+		list[i] = r.conductors[id]
+	}
+	return list, nil
+}
+
+func (r *MemoryRepository) PutConductor(c *Conductor) error {
+	if c == nil {
+		return &ConfigError{"MemoryRepository.PutConductor", "c", "== nil"}
+	}
+	if c.id == "" {
+		return &ConfigError{"MemoryRepository.PutConductor", "Conductor.Id", "is empty"}
+	}
+	if c.category == nil {
+		return &ConfigError{"MemoryRepository.PutConductor", "Conductor.Category", "== nil"}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.categories[c.category.id]; !ok {
+		return &NotFoundError{"MemoryRepository.PutConductor", "category", c.category.id}
+	}
+	r.conductors[c.id] = c
+	return nil
+}
+
+func (r *MemoryRepository) DeleteConductor(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.conductors[id]; !ok {
+		return &NotFoundError{"MemoryRepository.DeleteConductor", "conductor", id}
+	}
+	for _, ot := range r.tables {
+		for _, item := range ot.items {
+			if item.currentCalc.conductor.id == id {
+				return &ConfigError{"MemoryRepository.DeleteConductor", id, "used by table " + ot.id}
+			}
+		}
+	}
+	delete(r.conductors, id)
+	return nil
+}
+
+func (r *MemoryRepository) GetOperatingTable(id string) (*OperatingTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ot, ok := r.tables[id]
+	if !ok {
+		return nil, &NotFoundError{"MemoryRepository.GetOperatingTable", "table", id}
+	}
+	return ot, nil
+}
+
+func (r *MemoryRepository) ListOperatingTables() ([]*OperatingTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.tables))
+	for id := range r.tables {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	list := make([]*OperatingTable, len(ids))
+	for i, id := range ids {
+		list[i] = r.tables[id]
+	}
+	return list, nil
+}
+
+func (r *MemoryRepository) PutOperatingTable(ot *OperatingTable) error {
+	if ot == nil {
+		return &ConfigError{"MemoryRepository.PutOperatingTable", "ot", "== nil"}
+	}
+	if ot.id == "" {
+		return &ConfigError{"MemoryRepository.PutOperatingTable", "OperatingTable.Id", "is empty"}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, item := range ot.items {
+		id := item.currentCalc.conductor.id
+		if _, ok := r.conductors[id]; !ok {
+			return &NotFoundError{"MemoryRepository.PutOperatingTable", "conductor", id}
+		}
+	}
+	r.tables[ot.id] = ot
+	return nil
+}
+
+func (r *MemoryRepository) DeleteOperatingTable(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tables[id]; !ok {
+		return &NotFoundError{"MemoryRepository.DeleteOperatingTable", "table", id}
+	}
+	delete(r.tables, id)
+	return nil
+}
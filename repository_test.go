@@ -0,0 +1,208 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func getRepositoryConductor() *Conductor {
+	return NewConductor("AAAC 740,8 MCM FLINT", CC_AAAC, 25.17, 375.4, 1.035, 11250, 0.089360,
+		1e-10, "FLINT")
+}
+
+func fillRepository(t *testing.T, r Repository) {
+	if err := r.PutCategory(CC_AAAC); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.PutCategory(CC_ACSR); err != nil {
+		t.Fatal(err)
+	}
+	cond := getRepositoryConductor()
+	if err := r.PutConductor(cond); err != nil {
+		t.Fatal(err)
+	}
+	cc, _ := NewCurrentCalc(cond)
+	cc.SetAltitude(1200)
+	cc.SetWindAngle(45)
+	cc.SetFormula(CF_CLASSIC)
+	cc.SetIterMax(50)
+	opi1, _ := NewOperatingItem(cc, 50, 2)
+	opi2, _ := NewOperatingItem(cc, 75, 2)
+	ot, _ := NewOperatingTable([]*OperatingItem{opi1, opi2}, "LINE-1")
+	if err := r.PutOperatingTable(ot); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testRepository(t *testing.T, r Repository) {
+	fillRepository(t, r)
+
+	cats, _ := r.ListCategories()
+	if len(cats) != 2 || cats[0].Id() != "AAAC" || cats[1].Id() != "ACSR" {
+		t.Errorf("Categories AAAC, ACSR expected got %v", cats)
+	}
+	cond, err := r.GetConductor("FLINT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cond.Diameter() != 25.17 || cond.R25() != 0.089360 {
+		t.Error("Conductor values !=")
+	}
+	cat, _ := r.GetCategory("AAAC")
+	if cond.Category() != cat {
+		t.Error("Conductor.Category must be the stored *Category")
+	}
+	ot, err := r.GetOperatingTable("LINE-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ot.Len() != 2 {
+		t.Error("Len = 2 expected")
+	}
+	items := ot.Items()
+	if items[0].CurrentCalc().Conductor() != cond {
+		t.Error("OperatingItem conductor must be the stored *Conductor")
+	}
+	if items[0].CurrentCalc().Altitude() != 1200 || items[0].CurrentCalc().Formula() != CF_CLASSIC {
+		t.Error("CurrentCalc values !=")
+	}
+	if items[1].TempMaxOp() != 75 || items[1].Nsc() != 2 {
+		t.Error("OperatingItem values !=")
+	}
+
+	// References
+	if _, err := r.GetConductor("NONE"); err == nil {
+		t.Error("Not found error expected")
+	}
+	bad := NewConductor("X", CC_CU, 10, 0, 0, 0, 0.1, 1e-10, "X")
+	if err := r.PutConductor(bad); err == nil {
+		t.Error("Category not stored error expected")
+	}
+	if err := r.DeleteCategory("AAAC"); err == nil {
+		t.Error("Category in use error expected")
+	}
+	if err := r.DeleteConductor("FLINT"); err == nil {
+		t.Error("Conductor in use error expected")
+	}
+
+	// Delete
+	if err := r.DeleteOperatingTable("LINE-1"); err != nil {
+		t.Error(err)
+	}
+	if err := r.DeleteConductor("FLINT"); err != nil {
+		t.Error(err)
+	}
+	if err := r.DeleteCategory("AAAC"); err != nil {
+		t.Error(err)
+	}
+	if err := r.DeleteCategory("AAAC"); err == nil {
+		t.Error("Not found error expected")
+	}
+	cats, _ = r.ListCategories()
+	if len(cats) != 1 {
+		t.Error("Len = 1 expected")
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_MemoryRepository(t *testing.T) {
+	testRepository(t, NewMemoryRepository())
+}
+
+func Test_FileRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conductor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fr, err := OpenFileRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRepository(t, fr)
+}
+
+func Test_FileRepository_Reopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conductor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fr, _ := OpenFileRepository(dir)
+	fillRepository(t, fr)
+
+	fr, err = OpenFileRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ot, err := fr.GetOperatingTable("LINE-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := ot.Items()
+	cond, _ := fr.GetConductor("FLINT")
+	if items[0].CurrentCalc().Conductor() != cond {
+		t.Error("OperatingItem conductor must be the stored *Conductor")
+	}
+	if cond.Category() == CC_AAAC {
+		t.Error("Category must be loaded from repository")
+	}
+	if cond.Category().Modelas() != CC_AAAC.Modelas() {
+		t.Error("Category values !=")
+	}
+	if x := items[1].CurrentCalc(); x.Formula() != CF_CLASSIC || x.IterMax() != 50 ||
+		x.WindAngle() != 45 {
+		t.Errorf("CLASSIC, IterMax 50 and WindAngle 45 expected got %s %d %f", x.Formula(),
+			x.IterMax(), x.WindAngle())
+	}
+	x1, _ := ot.Current(30)
+	cc, _ := NewCurrentCalc(getRepositoryConductor())
+	cc.SetAltitude(1200)
+	cc.SetWindAngle(45)
+	cc.SetFormula(CF_CLASSIC)
+	x2, _ := cc.Current(30, 50)
+	if x1 != x2 {
+		t.Errorf("Current %f expected got %f", x2, x1)
+	}
+}
+
+func Test_FileRepository_Model(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conductor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fr, _ := OpenFileRepository(dir)
+	fillRepository(t, fr)
+	cond, _ := fr.GetConductor("FLINT")
+	cc, _ := NewCurrentCalc(cond)
+	cc.SetModel(&shadeModel{ieeeModel}) // Not registered instance
+	opi, _ := NewOperatingItem(cc, 50, 1)
+	ot, _ := NewOperatingTable([]*OperatingItem{opi}, "LINE-2")
+	if err := fr.PutOperatingTable(ot); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Model not registered error expected got %v", err)
+	}
+	if _, err := fr.GetOperatingTable("LINE-2"); err == nil {
+		t.Error("Table must not be stored")
+	}
+	cc.SetModel(getShadeModel())
+	if err := fr.PutOperatingTable(ot); err != nil {
+		t.Fatal(err)
+	}
+	fr, err = OpenFileRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ot, _ = fr.GetOperatingTable("LINE-2")
+	if m := ot.Items()[0].CurrentCalc().Model(); m != getShadeModel() {
+		t.Errorf("SHADE model expected got %s", m.Name())
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// NewSagTensionCalc Returns *SagTensionCalc object for a level span
+// conductor *Conductor : *Conductor instance (weight, area, modelas and coefexp > 0)
+// span      float64    : Span length [m] (required span > 0)
+// t0        float64    : Conductor temperature of the reference state [°C]
+// h0        float64    : Horizontal tension of the reference state [kg] (0 < h0 <= TENSION_MAX)
+func NewSagTensionCalc(conductor *Conductor, span float64, t0 float64,
+	h0 float64) (*SagTensionCalc, error) {
+	if conductor == nil {
+		return nil, &ConfigError{"NewSagTensionCalc", "Conductor", "== nil"}
+	}
+	if conductor.category == nil {
+		return nil, &ConfigError{"NewSagTensionCalc", "Conductor.Category", "== nil"}
+	}
+	if conductor.weight <= 0 {
+		return nil, &RangeError{"NewSagTensionCalc", "Conductor.Weight", "<=", "0", 0,
+			conductor.weight}
+	}
+	if conductor.area <= 0 {
+		return nil, &RangeError{"NewSagTensionCalc", "Conductor.Area", "<=", "0", 0, conductor.area}
+	}
+	if conductor.category.modelas <= 0 {
+		return nil, &RangeError{"NewSagTensionCalc", "Conductor.Category.Modelas", "<=", "0", 0,
+			conductor.category.modelas}
+	}
+	if conductor.category.coefexp <= 0 {
+		return nil, &RangeError{"NewSagTensionCalc", "Conductor.Category.Coefexp", "<=", "0", 0,
+			conductor.category.coefexp}
+	}
+	if span <= 0 {
+		return nil, &RangeError{"NewSagTensionCalc", "span", "<=", "0", 0, span}
+	}
+	if t0 < TC_MIN {
+		return nil, &RangeError{"NewSagTensionCalc", "t0", "<", "TC_MIN", TC_MIN, t0}
+	}
+	if t0 > TC_MAX {
+		return nil, &RangeError{"NewSagTensionCalc", "t0", ">", "TC_MAX", TC_MAX, t0}
+	}
+	if h0 <= 0 {
+		return nil, &RangeError{"NewSagTensionCalc", "h0", "<=", "0", 0, h0}
+	}
+	if h0 > TENSION_MAX {
+		return nil, &RangeError{"NewSagTensionCalc", "h0", ">", "TENSION_MAX", TENSION_MAX, h0}
+	}
+	return &SagTensionCalc{conductor, span, t0, h0}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// SagTensionCalc Object to calculate conductor tension and sag at any temperature for a
+// level span, from a reference state, using the parabolic change of state equation:
+// H²(H - H0 + EAw0²L²/(24H0²) + EAα(t - t0)) = EAw²L²/24, with conductor weight w0 and
+// unit load w (w = w0 without ice and wind)
+type SagTensionCalc struct {
+	conductor *Conductor // *Conductor instance
+	span      float64    // Span length [m]
+	t0        float64    // Conductor temperature of the reference state [°C]
+	h0        float64    // Horizontal tension of the reference state [kg]
+}
+
+// Tension Returns the horizontal tension [kg] at conductor temperature t [°C]
+func (st *SagTensionCalc) Tension(t float64) (float64, error) {
+	if t < TC_MIN {
+		return math.NaN(), &RangeError{"SagTensionCalc.Tension", "t", "<", "TC_MIN", TC_MIN, t}
+	}
+	if t > TC_MAX {
+		return math.NaN(), &RangeError{"SagTensionCalc.Tension", "t", ">", "TC_MAX", TC_MAX, t}
+	}
+	h, iter, ok := st.tension(t, st.conductor.weight, 0)
+	if !ok {
+		return math.NaN(), &ConvergenceError{"SagTensionCalc.Tension", iter}
+	}
+	return h, nil
+}
+
+// TensionAt Returns the horizontal tension [kg] at conductor temperature t [°C] under unit
+// load w [kg/m], e.g. UnitLoad.Resultant of ice and wind. The reference state is unloaded.
+func (st *SagTensionCalc) TensionAt(t float64, w float64) (float64, error) {
+	op := "SagTensionCalc.TensionAt"
+	if t < TC_MIN {
+		return math.NaN(), &RangeError{op, "t", "<", "TC_MIN", TC_MIN, t}
+	}
+	if t > TC_MAX {
+		return math.NaN(), &RangeError{op, "t", ">", "TC_MAX", TC_MAX, t}
+	}
+	if w <= 0 {
+		return math.NaN(), &RangeError{op, "w", "<=", "0", 0, w}
+	}
+	h, iter, ok := st.tension(t, w, 0)
+	if !ok {
+		return math.NaN(), &ConvergenceError{op, iter}
+	}
+	return h, nil
+}
+
+// LoadCaseTension Returns the horizontal tension [kg] and the unit loads of load case lc
+func (st *SagTensionCalc) LoadCaseTension(lc LoadCase) (float64, *UnitLoad, error) {
+	ul, err := lc.UnitLoad(st.conductor)
+	if err != nil {
+		return math.NaN(), nil, &OpError{"SagTensionCalc.LoadCaseTension", err}
+	}
+	h, err := st.TensionAt(lc.Temperature, ul.Resultant)
+	if err != nil {
+		return math.NaN(), nil, &OpError{"SagTensionCalc.LoadCaseTension", err}
+	}
+	return h, ul, nil
+}
+
+// Sag Returns the mid span sag [m] at conductor temperature t [°C]
+func (st *SagTensionCalc) Sag(t float64) (float64, error) {
+	h, err := st.Tension(t)
+	if err != nil {
+		return math.NaN(), &OpError{"SagTensionCalc.Sag", err}
+	}
+	return st.SagAt(h), nil
+}
+
+// SagAt Returns the mid span sag [m] for horizontal tension h [kg]
+func (st *SagTensionCalc) SagAt(h float64) float64 {
+	return st.conductor.weight * st.span * st.span / (8 * h)
+}
+
+// tension Solves the change of state equation at temperature t and unit load w with
+// permanent strain [mm/mm] added after the reference state (conductor weight):
+// H²(H - k) = EAw²L²/24. The cubic H²(H - k) - m is increasing for H > max(k, 0), where
+// it is negative, and positive at max(k, 0) + m^(1/3). Returns the tension, the
+// iterations of the solver and false if it did not converge.
+func (st *SagTensionCalc) tension(t float64, w float64, strain float64) (float64, int,
+	bool) {
+	c := st.conductor
+	ea := c.category.modelas * c.area
+	m0 := ea * c.weight * c.weight * st.span * st.span / 24
+	m := ea * w * w * st.span * st.span / 24
+	k := st.h0 - m0/(st.h0*st.h0) - ea*(c.category.coefexp*(t-st.t0)+strain)
+	f := func(h float64) float64 {
+		return h*h*(h-k) - m
+	}
+	a := math.Max(k, 0)
+	b := a + math.Cbrt(m)
+	return brent(f, a, b, f(a), f(b), 1e-6*st.h0, ITER_MAX, false)
+}
+
+func (st *SagTensionCalc) Conductor() *Conductor {
+	return st.conductor
+}
+
+func (st *SagTensionCalc) Span() float64 {
+	return st.span
+}
+
+func (st *SagTensionCalc) T0() float64 {
+	return st.t0
+}
+
+func (st *SagTensionCalc) H0() float64 {
+	return st.h0
+}
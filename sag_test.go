@@ -0,0 +1,154 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getSagTensionCalc() *SagTensionCalc {
+	st, _ := NewSagTensionCalc(getValidConductor(), 300, 15, 2250)
+	return st
+}
+
+func Test_NewSagTensionCalc(t *testing.T) {
+	c := getValidConductor()
+	st, err := NewSagTensionCalc(c, 300, 15, 2250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Conductor() != c || st.Span() != 300 || st.T0() != 15 || st.H0() != 2250 {
+		t.Error("SagTensionCalc values !=")
+	}
+
+	if _, err := NewSagTensionCalc(nil, 300, 15, 2250); err == nil {
+		t.Error("Conductor nil error expected")
+	}
+	if _, err := NewSagTensionCalc(getConductor(), 300, 15, 2250); err == nil {
+		t.Error("Weight=0 error expected")
+	}
+	if _, err := NewSagTensionCalc(c, 0, 15, 2250); err == nil {
+		t.Error("Span=0 error expected")
+	}
+	if _, err := NewSagTensionCalc(c, 300, TC_MAX+1, 2250); err == nil {
+		t.Error("T0>TC_MAX error expected")
+	}
+	if _, err := NewSagTensionCalc(c, 300, 15, 0); err == nil {
+		t.Error("H0=0 error expected")
+	}
+	if _, err := NewSagTensionCalc(c, 300, 15, TENSION_MAX+1); err == nil {
+		t.Error("H0>TENSION_MAX error expected")
+	}
+}
+
+func Test_SagTensionCalc_Tension(t *testing.T) {
+	st := getSagTensionCalc()
+	h, err := st.Tension(15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(h-2250) > 0.01 {
+		t.Errorf("Tension at t0 = 2250 expected got %f", h)
+	}
+
+	// Change of state equation holds and tension decreases with temperature
+	c := st.Conductor()
+	ea := c.Category().Modelas() * c.Area()
+	m := ea * c.Weight() * c.Weight() * 300 * 300 / 24
+	last := math.Inf(1)
+	for _, temp := range []float64{-10, 15, 50, 75, 100, 150} {
+		h, err := st.Tension(temp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := h - 2250 - m/(h*h) + m/(2250*2250) + ea*c.Category().Coefexp()*(temp-15)
+		if math.Abs(r) > 0.01 {
+			t.Errorf("Change of state residual %f at %f", r, temp)
+		}
+		if h >= last {
+			t.Errorf("Tension must decrease with temperature (%f at %f)", h, temp)
+		}
+		last = h
+	}
+
+	if _, err := st.Tension(TC_MIN - 1); err == nil {
+		t.Error("T<TC_MIN error expected")
+	}
+	if _, err := st.Tension(TC_MAX + 1); err == nil {
+		t.Error("T>TC_MAX error expected")
+	}
+}
+
+func Test_SagTensionCalc_TensionAt(t *testing.T) {
+	st := getSagTensionCalc()
+	c := st.Conductor()
+	h50, _ := st.Tension(50)
+	if h, _ := st.TensionAt(50, c.Weight()); h != h50 {
+		t.Errorf("%f expected got %f", h50, h)
+	}
+
+	// Change of state from the unloaded reference state to a loaded state
+	ea := c.Category().Modelas() * c.Area()
+	m0 := ea * c.Weight() * c.Weight() * 300 * 300 / 24
+	w := 2.5
+	m := ea * w * w * 300 * 300 / 24
+	h, err := st.TensionAt(-10, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := h - 2250 - m/(h*h) + m0/(2250*2250) + ea*c.Category().Coefexp()*(-10-15)
+	if math.Abs(r) > 0.01 {
+		t.Errorf("Change of state residual %f", r)
+	}
+	if bare, _ := st.Tension(-10); h <= bare {
+		t.Errorf("Loaded tension greater than %f expected got %f", bare, h)
+	}
+
+	hl, ul, err := st.LoadCaseTension(NESC_HEAVY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, _ := st.TensionAt(NESC_HEAVY.Temperature, ul.Resultant); hl != x {
+		t.Errorf("%f expected got %f", x, hl)
+	}
+
+	if _, err := st.TensionAt(15, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	if _, err := st.TensionAt(TC_MAX+1, w); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+	if _, _, err := st.LoadCaseTension(LoadCase{"", 15, -1, 0, 0, 0}); !errors.Is(err,
+		ErrOutOfRange) {
+		t.Errorf("Range error expected got %v", err)
+	}
+}
+
+func Test_SagTensionCalc_Sag(t *testing.T) {
+	st := getSagTensionCalc()
+	sag, _ := st.Sag(15)
+	if math.Abs(sag-1.035*300*300/(8*2250)) > 1e-4 {
+		t.Errorf("Sag %f expected got %f", 1.035*300*300/(8*2250), sag)
+	}
+	if _, err := st.Sag(TC_MAX + 1); err == nil {
+		t.Error("T>TC_MAX error expected")
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func ExampleSagTensionCalc_Sag() {
+	st := getSagTensionCalc()
+	for _, t := range []float64{15, 50, 75} {
+		h, _ := st.Tension(t)
+		sag, _ := st.Sag(t)
+		fmt.Printf("%.0f°C %.1f kg %.2f m\n", t, h, sag)
+	}
+	// Output:
+	// 15°C 2250.0 kg 5.18 m
+	// 50°C 1709.2 kg 6.81 m
+	// 75°C 1472.6 kg 7.91 m
+}
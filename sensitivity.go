@@ -0,0 +1,188 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"sort"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Parameters for sensitivity analysis of CurrentCalc.Current
+// SP_TA           = "TA"            Ambient temperature [°C]
+// SP_AIR_VELOCITY = "AIR_VELOCITY"  Velocity of air stream [ft/seg]
+// SP_WIND_ANGLE   = "WIND_ANGLE"    Angle between air stream and conductor axis [°]
+// SP_SUN_EFFECT   = "SUN_EFFECT"    Sun effect factor (0 to 1)
+// SP_EMISSIVITY   = "EMISSIVITY"    Emissivity (0 to 1)
+// SP_ALTITUDE     = "ALTITUDE"      Altitude [m]
+// SP_DIAMETER     = "DIAMETER"      Conductor diameter [mm]
+// SP_RESISTANCE   = "RESISTANCE"    Conductor resistance at 25°C [Ohm/km]
+const (
+	SP_TA           = "TA"
+	SP_AIR_VELOCITY = "AIR_VELOCITY"
+	SP_WIND_ANGLE   = "WIND_ANGLE"
+	SP_SUN_EFFECT   = "SUN_EFFECT"
+	SP_EMISSIVITY   = "EMISSIVITY"
+	SP_ALTITUDE     = "ALTITUDE"
+	SP_DIAMETER     = "DIAMETER"
+	SP_RESISTANCE   = "RESISTANCE"
+)
+
+// SP_ALL Parameters evaluated by CurrentCalc.Sensitivity
+var SP_ALL = []string{SP_TA, SP_AIR_VELOCITY, SP_WIND_ANGLE, SP_SUN_EFFECT, SP_EMISSIVITY,
+	SP_ALTITUDE, SP_DIAMETER, SP_RESISTANCE}
+
+// SENSITIVITY_DELTAS Default variations used for the tornado ranking. Variations are in
+// parameter units, except SP_DIAMETER and SP_RESISTANCE that are fractions of the value.
+var SENSITIVITY_DELTAS = map[string]float64{
+	SP_TA:           5.0,
+	SP_AIR_VELOCITY: 1.0,
+	SP_WIND_ANGLE:   30.0,
+	SP_SUN_EFFECT:   0.25,
+	SP_EMISSIVITY:   0.2,
+	SP_ALTITUDE:     500.0,
+	SP_DIAMETER:     0.05,
+	SP_RESISTANCE:   0.05,
+}
+
+// Steps for finite differences in parameter units (fractions for diameter and resistance)
+var sensitivitySteps = map[string]float64{
+	SP_TA:           0.01,
+	SP_AIR_VELOCITY: 0.001,
+	SP_WIND_ANGLE:   0.01,
+	SP_SUN_EFFECT:   0.0001,
+	SP_EMISSIVITY:   0.0001,
+	SP_ALTITUDE:     0.1,
+	SP_DIAMETER:     0.0001,
+	SP_RESISTANCE:   0.0001,
+}
+
+//----------------------------------------------------------------------------------------
+
+// Sensitivity Sensitivity of CurrentCalc.Current to one parameter
+type Sensitivity struct {
+	Param      string  // Parameter SP_*
+	Value      float64 // Base value of the parameter
+	Derivative float64 // Partial derivative dI/dParam [A/(parameter unit)]
+	Elasticity float64 // Relative sensitivity (Value/I)*dI/dParam
+	Delta      float64 // Variation of the parameter used for Low and High
+	Low        float64 // Current with Value - Delta [A]
+	High       float64 // Current with Value + Delta [A]
+	Swing      float64 // |High - Low| [A], used for the tornado ranking
+}
+
+//----------------------------------------------------------------------------------------
+
+// Sensitivity Returns the sensitivity of Current(ta, tc) to every parameter in SP_ALL,
+// sorted by decreasing Swing (tornado ranking). Derivatives are central finite differences.
+// Variations are clipped to the valid range of each parameter.
+// ta     float64            : Ambient temperature [°C]
+// tc     float64            : Conductor temperature [°C]
+// deltas map[string]float64 : Variations for the ranking (nil uses SENSITIVITY_DELTAS)
+func (cc *CurrentCalc) Sensitivity(ta float64, tc float64,
+	deltas map[string]float64) ([]Sensitivity, error) {
+	base, err := cc.Current(ta, tc)
+	if err != nil {
+		return nil, &OpError{"CurrentCalc.Sensitivity", err}
+	}
+	list := make([]Sensitivity, len(SP_ALL))
+	for i, p := range SP_ALL {
+		value := cc.paramValue(p, ta)
+		delta, ok := deltas[p]
+		if !ok {
+			delta = SENSITIVITY_DELTAS[p]
+		}
+		if delta < 0 {
+			return nil, &RangeError{"CurrentCalc.Sensitivity", "delta " + p, "<", "0", 0, delta}
+		}
+		step := sensitivitySteps[p]
+		if p == SP_DIAMETER || p == SP_RESISTANCE {
+			delta *= value
+			step *= value
+		}
+
+		s := Sensitivity{Param: p, Value: value, Delta: delta}
+		x1, f1 := cc.paramCurrent(p, value-step, ta, tc)
+		x2, f2 := cc.paramCurrent(p, value+step, ta, tc)
+		if x2 > x1 {
+			s.Derivative = (f2 - f1) / (x2 - x1)
+		}
+		if base > 0 {
+			s.Elasticity = value / base * s.Derivative
+		}
+		_, s.Low = cc.paramCurrent(p, value-delta, ta, tc)
+		_, s.High = cc.paramCurrent(p, value+delta, ta, tc)
+		s.Swing = math.Abs(s.High - s.Low)
+		list[i] = s
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Swing > list[j].Swing
+	})
+	return list, nil
+}
+
+// paramValue Returns current value of parameter p
+func (cc *CurrentCalc) paramValue(p string, ta float64) float64 {
+	switch p {
+	case SP_TA:
+		return ta
+	case SP_AIR_VELOCITY:
+		return cc.airVelocity
+	case SP_WIND_ANGLE:
+		return cc.windAngle
+	case SP_SUN_EFFECT:
+		return cc.sunEffect
+	case SP_EMISSIVITY:
+		return cc.emissivity
+	case SP_ALTITUDE:
+		return cc.altitude
+	case SP_DIAMETER:
+		return cc.conductor.diameter
+	case SP_RESISTANCE:
+		return cc.conductor.r25
+	}
+	return math.NaN()
+}
+
+// paramCurrent Returns parameter value clipped to its valid range and Current(ta, tc)
+// evaluated with that value. cc is not modified.
+func (cc *CurrentCalc) paramCurrent(p string, x float64, ta float64, tc float64) (float64,
+	float64) {
+	c := *cc
+	cond := *cc.conductor
+	c.conductor = &cond
+	switch p {
+	case SP_TA:
+		x = clip(x, TA_MIN, math.Min(TA_MAX, tc))
+		ta = x
+	case SP_AIR_VELOCITY:
+		x = math.Max(x, 0)
+		c.airVelocity = x
+	case SP_WIND_ANGLE:
+		x = clip(x, 0, 90)
+		c.windAngle = x
+	case SP_SUN_EFFECT:
+		x = clip(x, 0, 1)
+		c.sunEffect = x
+	case SP_EMISSIVITY:
+		x = clip(x, 0, 1)
+		c.emissivity = x
+	case SP_ALTITUDE:
+		x = math.Max(x, 0)
+		c.altitude = x
+	case SP_DIAMETER:
+		x = math.Max(x, cc.conductor.diameter*1e-3)
+		cond.diameter = x
+	case SP_RESISTANCE:
+		x = math.Max(x, cc.conductor.r25*1e-3)
+		cond.r25 = x
+	}
+	cur, _ := c.Current(ta, tc) // ta y tc ya verificados
+	return x, cur
+}
+
+// clip Returns x limited to range [lo, hi]
+func clip(x float64, lo float64, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
@@ -0,0 +1,83 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func Test_CurrentCalc_Sensitivity(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	cc.SetWindAngle(60)
+	base, _ := cc.Current(25, 50)
+
+	list, err := cc.Sensitivity(25, 50, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(SP_ALL) {
+		t.Fatalf("%d sensitivities expected got %d", len(SP_ALL), len(list))
+	}
+	for i, s := range list {
+		if i > 0 && s.Swing > list[i-1].Swing {
+			t.Error("Sorted by decreasing Swing expected")
+		}
+		switch s.Param {
+		case SP_TA, SP_SUN_EFFECT, SP_RESISTANCE:
+			if s.Derivative >= 0 {
+				t.Errorf("%s: negative derivative expected got %f", s.Param, s.Derivative)
+			}
+		case SP_AIR_VELOCITY, SP_WIND_ANGLE, SP_DIAMETER:
+			if s.Derivative <= 0 {
+				t.Errorf("%s: positive derivative expected got %f", s.Param, s.Derivative)
+			}
+		}
+		if s.Param == SP_RESISTANCE && math.Abs(s.Elasticity+0.5) > 1e-3 {
+			t.Errorf("Elasticity to resistance -0.5 expected got %f", s.Elasticity)
+		}
+	}
+
+	// Derivative agrees with a manual finite difference
+	cc2, _ := NewCurrentCalc(getConductor())
+	cc2.SetWindAngle(60)
+	cc2.SetAirVelocity(cc.AirVelocity() + 0.01)
+	x, _ := cc2.Current(25, 50)
+	for _, s := range list {
+		if s.Param == SP_AIR_VELOCITY {
+			d := (x - base) / 0.01
+			if math.Abs(s.Derivative-d)/d > 0.01 {
+				t.Errorf("Derivative %f expected got %f", d, s.Derivative)
+			}
+		}
+	}
+
+	// Calculator is not modified
+	if cc.AirVelocity() != 2 || cc.Conductor().Diameter() != 25.17 {
+		t.Error("CurrentCalc must not be modified")
+	}
+
+	_, err = cc.Sensitivity(TA_MIN-1, 50, nil)
+	if err == nil {
+		t.Error("ta < TA_MIN error expected")
+	}
+	_, err = cc.Sensitivity(25, 50, map[string]float64{SP_TA: -1})
+	if err == nil {
+		t.Error("delta < 0 error expected")
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Example_CurrentCalc_Sensitivity() {
+	cc, _ := NewCurrentCalc(getConductor())
+	list, _ := cc.Sensitivity(25, 50, nil)
+	for _, s := range list[:3] {
+		fmt.Printf("%s %.1f\n", s.Param, s.Swing)
+	}
+	// Output:
+	// AIR_VELOCITY 172.8
+	// TA 152.5
+	// EMISSIVITY 53.7
+}
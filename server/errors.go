@@ -0,0 +1,151 @@
+// Copyright Cristian Echeverría Rabí
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/cer1969/go-conductor"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Error codes of ErrorBody
+// CODE_BAD_REQUEST        = "BAD_REQUEST"         Invalid JSON, missing or invalid field (400)
+// CODE_OUT_OF_RANGE       = "OUT_OF_RANGE"        conductor.ErrOutOfRange (400)
+// CODE_INVALID_CONFIG     = "INVALID_CONFIG"      conductor.ErrInvalidConfig (400)
+// CODE_VALIDATION         = "VALIDATION"          *conductor.ValidationError (400)
+// CODE_NOT_FOUND          = "NOT_FOUND"           conductor.ErrNotFound or unknown path (404)
+// CODE_METHOD_NOT_ALLOWED = "METHOD_NOT_ALLOWED"  Wrong HTTP method (405)
+// CODE_BODY_TOO_LARGE     = "BODY_TOO_LARGE"      Request body larger than MAX_BODY (413)
+// CODE_CONVERGENCE        = "CONVERGENCE"         conductor.ErrConvergence (422)
+// CODE_INTERNAL           = "INTERNAL"            Any other error (500)
+const (
+	CODE_BAD_REQUEST        = "BAD_REQUEST"
+	CODE_OUT_OF_RANGE       = "OUT_OF_RANGE"
+	CODE_INVALID_CONFIG     = "INVALID_CONFIG"
+	CODE_VALIDATION         = "VALIDATION"
+	CODE_NOT_FOUND          = "NOT_FOUND"
+	CODE_METHOD_NOT_ALLOWED = "METHOD_NOT_ALLOWED"
+	CODE_BODY_TOO_LARGE     = "BODY_TOO_LARGE"
+	CODE_CONVERGENCE        = "CONVERGENCE"
+	CODE_INTERNAL           = "INTERNAL"
+)
+
+//----------------------------------------------------------------------------------------
+
+// ErrorBody Error returned by the service as {"error": ErrorBody}
+type ErrorBody struct {
+	Code       string          `json:"code"`                 // CODE_*
+	Message    string          `json:"message"`              // Error message
+	Op         string          `json:"op,omitempty"`         // Library operation that failed
+	Field      string          `json:"field,omitempty"`      // Parameter or request field
+	Limit      string          `json:"limit,omitempty"`      // Name of the bound (OUT_OF_RANGE)
+	Bound      *float64        `json:"bound,omitempty"`      // Value of the bound (OUT_OF_RANGE)
+	Value      *float64        `json:"value,omitempty"`      // Value received (OUT_OF_RANGE)
+	Violations []ViolationBody `json:"violations,omitempty"` // Violations (VALIDATION)
+}
+
+// ViolationBody conductor.Violation of a VALIDATION error
+type ViolationBody struct {
+	Id    string   `json:"id"`
+	Field string   `json:"field"`
+	Value *float64 `json:"value,omitempty"`
+	Msg   string   `json:"msg"`
+}
+
+//----------------------------------------------------------------------------------------
+
+// statusError Error of the service not originated in the library
+type statusError struct {
+	status int
+	code   string
+	msg    string
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// badRequest Returns error for an invalid request field
+func badRequest(field string, msg string) error {
+	return &fieldError{statusError{http.StatusBadRequest, CODE_BAD_REQUEST, msg}, field}
+}
+
+// fieldError statusError of a request field
+type fieldError struct {
+	statusError
+	field string
+}
+
+//----------------------------------------------------------------------------------------
+
+// errorResponse Returns HTTP status and body for err
+func errorResponse(err error) (int, *ErrorBody) {
+	body := &ErrorBody{Code: CODE_INTERNAL, Message: err.Error()}
+	status := http.StatusInternalServerError
+
+	var ferr *fieldError
+	var serr *statusError
+	var rerr *conductor.RangeError
+	var cerr *conductor.ConfigError
+	var verr *conductor.ValidationError
+	var nerr *conductor.NotFoundError
+	var gerr *conductor.ConvergenceError
+	switch {
+	case errors.As(err, &ferr):
+		status, body.Code, body.Field = ferr.status, ferr.code, ferr.field
+	case errors.As(err, &serr):
+		status, body.Code = serr.status, serr.code
+	case errors.As(err, &rerr):
+		status, body.Code = http.StatusBadRequest, CODE_OUT_OF_RANGE
+		body.Op, body.Field, body.Limit = rerr.Op, rerr.Field, rerr.Limit
+		body.Bound, body.Value = number(rerr.Bound), number(rerr.Value)
+	case errors.As(err, &verr):
+		status, body.Code, body.Op = http.StatusBadRequest, CODE_VALIDATION, verr.Op
+		for _, v := range verr.Violations {
+			body.Violations = append(body.Violations, ViolationBody{v.Id, v.Field, number(v.Value),
+				v.Msg})
+		}
+	case errors.As(err, &cerr):
+		status, body.Code = http.StatusBadRequest, CODE_INVALID_CONFIG
+		body.Op, body.Field = cerr.Op, cerr.Field
+	case errors.As(err, &nerr):
+		status, body.Code, body.Op = http.StatusNotFound, CODE_NOT_FOUND, nerr.Op
+	case errors.As(err, &gerr):
+		status, body.Code, body.Op = http.StatusUnprocessableEntity, CODE_CONVERGENCE, gerr.Op
+	case errors.Is(err, conductor.ErrOutOfRange):
+		status, body.Code = http.StatusBadRequest, CODE_OUT_OF_RANGE
+	case errors.Is(err, conductor.ErrInvalidConfig):
+		status, body.Code = http.StatusBadRequest, CODE_INVALID_CONFIG
+	}
+	return status, body
+}
+
+// number Returns pointer to x or nil if x is not a valid JSON number
+func number(x float64) *float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil
+	}
+	return &x
+}
+
+// writeError Writes the error response for err
+func writeError(w http.ResponseWriter, err error) {
+	status, body := errorResponse(err)
+	writeJSON(w, status, struct {
+		Error *ErrorBody `json:"error"`
+	}{body})
+}
+
+// writeJSON Writes v as JSON with HTTP status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,247 @@
+// Copyright Cristian Echeverría Rabí
+
+package server
+
+// OPENAPI OpenAPI 3.0 description of the service served at /openapi.json
+const OPENAPI = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Conductor rating service",
+    "description": "Ampacity, conductor temperature and ambient temperature of high voltage conductors. Temperatures in °C, currents in A, altitude in m and air velocity in ft/s.",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/v1/current": {
+      "post": {
+        "summary": "Current for ambient temperature ta and conductor temperature tc",
+        "requestBody": {"$ref": "#/components/requestBodies/Calc"},
+        "responses": {
+          "200": {"$ref": "#/components/responses/Calc"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tc": {
+      "post": {
+        "summary": "Conductor temperature for ambient temperature ta and current ic",
+        "requestBody": {"$ref": "#/components/requestBodies/Calc"},
+        "responses": {
+          "200": {"$ref": "#/components/responses/Calc"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/ta": {
+      "post": {
+        "summary": "Ambient temperature for conductor temperature tc and current ic",
+        "requestBody": {"$ref": "#/components/requestBodies/Calc"},
+        "responses": {
+          "200": {"$ref": "#/components/responses/Calc"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/operating-item/current": {
+      "post": {
+        "summary": "Current of an operating item (tempMaxOp, nsc) for ambient temperature ta",
+        "requestBody": {"$ref": "#/components/requestBodies/Calc"},
+        "responses": {
+          "200": {"$ref": "#/components/responses/Calc"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tables": {
+      "get": {
+        "summary": "Ids of the operating tables in the repository",
+        "responses": {
+          "200": {
+            "description": "Table ids",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TablesResponse"}}}
+          }
+        }
+      }
+    },
+    "/v1/tables/{id}/current": {
+      "get": {
+        "summary": "Current of an operating table (minimum of its items) for ambient temperature ta",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+          {"name": "ta", "in": "query", "required": true, "schema": {"type": "number"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "Table current",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TableResponse"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "responses": {"200": {"description": "OpenAPI description"}}
+      }
+    }
+  },
+  "components": {
+    "requestBodies": {
+      "Calc": {
+        "required": true,
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/CalcRequest"}}}
+      }
+    },
+    "responses": {
+      "Calc": {
+        "description": "Result",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/CalcResponse"}}}
+      },
+      "Error": {
+        "description": "Error",
+        "content": {
+          "application/json": {
+            "schema": {
+              "type": "object",
+              "required": ["error"],
+              "properties": {"error": {"$ref": "#/components/schemas/Error"}}
+            }
+          }
+        }
+      }
+    },
+    "schemas": {
+      "CalcRequest": {
+        "type": "object",
+        "description": "current requires ta and tc, tc requires ta and ic, ta requires tc and ic, operating-item/current requires ta, tempMaxOp and nsc",
+        "required": ["conductor"],
+        "additionalProperties": false,
+        "properties": {
+          "conductor": {
+            "description": "Conductor id in the repository or conductor data",
+            "oneOf": [{"type": "string"}, {"$ref": "#/components/schemas/Conductor"}]
+          },
+          "conditions": {"$ref": "#/components/schemas/Conditions"},
+          "airVelocityMs": {"type": "number", "minimum": 0, "description": "Velocity of air stream [m/s], replaces conditions.airVelocity"},
+          "formula": {"type": "string", "default": "IEEE", "description": "Registered heat balance model, IEEE and CLASSIC are built in"},
+          "deltaTemp": {"type": "number", "default": 0.01, "description": "Tolerance of tc and ta [°C]"},
+          "ta": {"type": "number", "minimum": -90, "maximum": 90, "description": "Ambient temperature [°C]"},
+          "tc": {"type": "number", "minimum": -90, "maximum": 2000, "description": "Conductor temperature [°C]"},
+          "ic": {"type": "number", "minimum": 0, "description": "Current [A]"},
+          "tempMaxOp": {"type": "number", "minimum": -90, "maximum": 2000, "description": "Maximum operating temperature [°C]"},
+          "nsc": {"type": "integer", "minimum": 1, "description": "Subconductors per phase"}
+        }
+      },
+      "Conditions": {
+        "type": "object",
+        "description": "Missing fields take default values",
+        "additionalProperties": false,
+        "properties": {
+          "altitude": {"type": "number", "minimum": 0, "default": 300, "description": "Altitude [m]"},
+          "airVelocity": {"type": "number", "minimum": 0, "default": 2, "description": "Velocity of air stream [ft/s]"},
+          "windAngle": {"type": "number", "minimum": 0, "maximum": 90, "default": 90, "description": "Angle between air stream and conductor axis [°]"},
+          "sunEffect": {"type": "number", "minimum": 0, "maximum": 1, "default": 1},
+          "emissivity": {"type": "number", "minimum": 0, "maximum": 1, "default": 0.5}
+        }
+      },
+      "Conductor": {
+        "type": "object",
+        "description": "Checked with Conductor.Validate, violations return a VALIDATION error",
+        "required": ["name", "category", "diameter", "area", "weight", "strength", "r25"],
+        "additionalProperties": false,
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "category": {
+            "description": "Category id in the repository or category data",
+            "oneOf": [{"type": "string"}, {"$ref": "#/components/schemas/Category"}]
+          },
+          "diameter": {"type": "number", "description": "Diameter [mm]"},
+          "area": {"type": "number", "description": "Cross section area [mm2]"},
+          "weight": {"type": "number", "description": "Weight per unit [kg/m]"},
+          "strength": {"type": "number", "description": "Rated strength [kg]"},
+          "r25": {"type": "number", "description": "Resistance at 25°C [Ohm/km]"},
+          "hcap": {"type": "number", "description": "Heat capacity [kcal/(ft*°C)]"}
+        }
+      },
+      "Category": {
+        "type": "object",
+        "required": ["name", "modelas", "coefexp", "alpha"],
+        "additionalProperties": false,
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "modelas": {"type": "number", "description": "Modulus of elasticity [kg/mm2]"},
+          "coefexp": {"type": "number", "description": "Coefficient of thermal expansion [1/°C]"},
+          "creep": {"type": "number", "description": "Creep [°C]"},
+          "alpha": {"type": "number", "description": "Temperature coefficient of resistance [1/°C]"}
+        }
+      },
+      "CalcResponse": {
+        "type": "object",
+        "properties": {
+          "conductor": {"type": "string"},
+          "ta": {"type": "number"},
+          "tc": {"type": "number"},
+          "current": {"type": "number"},
+          "iterations": {"type": "integer", "description": "tc and ta only"},
+          "nsc": {"type": "integer", "description": "Operating items only"},
+          "phase": {"type": "number", "description": "Current per phase nsc*current [A], operating items only"}
+        }
+      },
+      "TableResponse": {
+        "type": "object",
+        "properties": {
+          "table": {"type": "string"},
+          "ta": {"type": "number"},
+          "current": {"type": "number"},
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/CalcResponse"}}
+        }
+      },
+      "TablesResponse": {
+        "type": "object",
+        "properties": {"tables": {"type": "array", "items": {"type": "string"}}}
+      },
+      "Error": {
+        "type": "object",
+        "required": ["code", "message"],
+        "properties": {
+          "code": {
+            "type": "string",
+            "enum": ["BAD_REQUEST", "OUT_OF_RANGE", "INVALID_CONFIG", "VALIDATION", "NOT_FOUND", "METHOD_NOT_ALLOWED", "BODY_TOO_LARGE", "CONVERGENCE", "INTERNAL"]
+          },
+          "message": {"type": "string"},
+          "op": {"type": "string"},
+          "field": {"type": "string"},
+          "limit": {"type": "string"},
+          "bound": {"type": "number"},
+          "value": {"type": "number"},
+          "violations": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "id": {"type": "string"},
+                "field": {"type": "string"},
+                "value": {"type": "number"},
+                "msg": {"type": "string"}
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,397 @@
+// Copyright Cristian Echeverría Rabí
+
+// Package server exposes conductor ratings as an HTTP/JSON service.
+//
+// Endpoints (see OPENAPI for the full description):
+//
+//	POST /v1/current                 Current [A] for ta and tc
+//	POST /v1/tc                      Conductor temperature [°C] for ta and ic
+//	POST /v1/ta                      Ambient temperature [°C] for tc and ic
+//	POST /v1/operating-item/current  Current [A] of an OperatingItem for ta
+//	GET  /v1/tables                  Ids of the OperatingTables of the repository
+//	GET  /v1/tables/{id}/current     Current [A] of an OperatingTable for query ta
+//	GET  /openapi.json               OpenAPI description
+//
+// Errors are returned with a 4xx/5xx status and a JSON body {"error": {...}} whose code
+// identifies the library error (see ErrorBody).
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cer1969/go-conductor"
+)
+
+//----------------------------------------------------------------------------------------
+
+// MAX_BODY Maximum size of a request body [bytes]
+const MAX_BODY = 1 << 20
+
+//----------------------------------------------------------------------------------------
+
+// New Returns *Server using repository repo to resolve conductors and operating tables by
+// id. repo can be nil: requests must then include conductor data and table endpoints
+// return NOT_FOUND.
+func New(repo conductor.Repository) *Server {
+	s := &Server{repo: repo, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/v1/current", s.post(s.current))
+	s.mux.HandleFunc("/v1/tc", s.post(s.tc))
+	s.mux.HandleFunc("/v1/ta", s.post(s.ta))
+	s.mux.HandleFunc("/v1/operating-item/current", s.post(s.itemCurrent))
+	s.mux.HandleFunc("/v1/tables", s.get(s.tables))
+	s.mux.HandleFunc("/v1/tables/", s.get(s.tableCurrent))
+	s.mux.HandleFunc("/openapi.json", s.get(s.openapi))
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &statusError{http.StatusNotFound, CODE_NOT_FOUND,
+			"no endpoint " + r.URL.Path})
+	})
+	return s
+}
+
+//----------------------------------------------------------------------------------------
+
+// Server http.Handler for the rating service. Safe for concurrent use: every request
+// builds its own CurrentCalc.
+type Server struct {
+	repo conductor.Repository // Repository of conductors and tables (can be nil)
+	mux  *http.ServeMux       // Routes
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handler Handles a request and returns the response value or an error
+type handler func(r *http.Request) (interface{}, error)
+
+// post Returns http.HandlerFunc for h accepting only POST
+func (s *Server) post(h handler) http.HandlerFunc {
+	return s.method(http.MethodPost, h)
+}
+
+// get Returns http.HandlerFunc for h accepting only GET
+func (s *Server) get(h handler) http.HandlerFunc {
+	return s.method(http.MethodGet, h)
+}
+
+// method Returns http.HandlerFunc for h accepting only method m
+func (s *Server) method(m string, h handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != m {
+			w.Header().Set("Allow", m)
+			writeError(w, &statusError{http.StatusMethodNotAllowed, CODE_METHOD_NOT_ALLOWED,
+				r.Method + " not allowed (use " + m + ")"})
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, MAX_BODY)
+		v, err := h(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+//----------------------------------------------------------------------------------------
+// Requests and responses
+
+// CalcRequest Body of /v1/current, /v1/tc, /v1/ta and /v1/operating-item/current. Each
+// endpoint requires the temperatures and current it uses. Conductor is the id of a
+// conductor in the repository or a conductor.ConductorDoc object. Conditions is a
+// conductor.Conditions object whose missing fields take CONDITIONS_DEFAULT values.
+type CalcRequest struct {
+	Conductor  json.RawMessage `json:"conductor"`
+	Conditions json.RawMessage `json:"conditions,omitempty"`
+	// Velocity of air stream [m/s], replaces conditions.airVelocity [ft/s]
+	AirVelocityMs *float64 `json:"airVelocityMs,omitempty"`
+	Formula       string   `json:"formula,omitempty"`   // Registered model, IEEE (default)
+	DeltaTemp     *float64 `json:"deltaTemp,omitempty"` // Tolerance of tc and ta [°C]
+	Ta            *float64 `json:"ta,omitempty"`        // Ambient temperature [°C]
+	Tc            *float64 `json:"tc,omitempty"`        // Conductor temperature [°C]
+	Ic            *float64 `json:"ic,omitempty"`        // Current [A]
+	TempMaxOp     *float64 `json:"tempMaxOp,omitempty"` // Maximum operating temperature [°C]
+	Nsc           *int     `json:"nsc,omitempty"`       // Subconductors per phase
+}
+
+// CalcResponse Response of calculation endpoints. Only the fields of the endpoint are set.
+type CalcResponse struct {
+	Conductor  string   `json:"conductor"`            // Conductor id (name if id is empty)
+	Ta         float64  `json:"ta"`                   // Ambient temperature [°C]
+	Tc         float64  `json:"tc"`                   // Conductor temperature [°C]
+	Current    float64  `json:"current"`              // Current [A]
+	Iterations *int     `json:"iterations,omitempty"` // Iterations of tc and ta
+	Nsc        *int     `json:"nsc,omitempty"`        // Subconductors per phase
+	Phase      *float64 `json:"phase,omitempty"`      // Current per phase nsc*current [A]
+}
+
+// TableResponse Response of /v1/tables/{id}/current
+type TableResponse struct {
+	Table   string         `json:"table"`   // OperatingTable id
+	Ta      float64        `json:"ta"`      // Ambient temperature [°C]
+	Current float64        `json:"current"` // Current of the table (minimum of items) [A]
+	Items   []CalcResponse `json:"items"`   // Current of each item
+}
+
+// TablesResponse Response of /v1/tables
+type TablesResponse struct {
+	Tables []string `json:"tables"` // OperatingTable ids
+}
+
+//----------------------------------------------------------------------------------------
+
+func (s *Server) current(r *http.Request) (interface{}, error) {
+	req, cc, err := s.decodeCalc(r, "ta", "tc")
+	if err != nil {
+		return nil, err
+	}
+	cur, err := cc.Current(*req.Ta, *req.Tc)
+	if err != nil {
+		return nil, err
+	}
+	return &CalcResponse{Conductor: conductorId(cc.Conductor()), Ta: *req.Ta, Tc: *req.Tc,
+		Current: cur}, nil
+}
+
+func (s *Server) tc(r *http.Request) (interface{}, error) {
+	req, cc, err := s.decodeCalc(r, "ta", "ic")
+	if err != nil {
+		return nil, err
+	}
+	tc, iter, err := cc.TcIter(*req.Ta, *req.Ic)
+	if err != nil {
+		return nil, err
+	}
+	return &CalcResponse{Conductor: conductorId(cc.Conductor()), Ta: *req.Ta, Tc: tc,
+		Current: *req.Ic, Iterations: &iter}, nil
+}
+
+func (s *Server) ta(r *http.Request) (interface{}, error) {
+	req, cc, err := s.decodeCalc(r, "tc", "ic")
+	if err != nil {
+		return nil, err
+	}
+	ta, iter, err := cc.TaIter(*req.Tc, *req.Ic)
+	if err != nil {
+		return nil, err
+	}
+	return &CalcResponse{Conductor: conductorId(cc.Conductor()), Ta: ta, Tc: *req.Tc,
+		Current: *req.Ic, Iterations: &iter}, nil
+}
+
+func (s *Server) itemCurrent(r *http.Request) (interface{}, error) {
+	req, cc, err := s.decodeCalc(r, "ta", "tempMaxOp", "nsc")
+	if err != nil {
+		return nil, err
+	}
+	item, err := conductor.NewOperatingItem(cc, *req.TempMaxOp, *req.Nsc)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := item.Current(*req.Ta)
+	if err != nil {
+		return nil, err
+	}
+	return itemResponse(item, *req.Ta, cur), nil
+}
+
+func (s *Server) tables(r *http.Request) (interface{}, error) {
+	resp := &TablesResponse{Tables: []string{}}
+	if s.repo == nil {
+		return resp, nil
+	}
+	list, err := s.repo.ListOperatingTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, ot := range list {
+		resp.Tables = append(resp.Tables, ot.Id())
+	}
+	return resp, nil
+}
+
+func (s *Server) tableCurrent(r *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/tables/")
+	if !strings.HasSuffix(path, "/current") {
+		return nil, &statusError{http.StatusNotFound, CODE_NOT_FOUND, "no endpoint " + r.URL.Path}
+	}
+	id, err := url.PathUnescape(strings.TrimSuffix(path, "/current"))
+	if err != nil || id == "" {
+		return nil, badRequest("id", "invalid table id")
+	}
+	q := r.URL.Query().Get("ta")
+	if q == "" {
+		return nil, badRequest("ta", "query parameter ta is required")
+	}
+	ta, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return nil, badRequest("ta", "query parameter ta is not a number")
+	}
+	if s.repo == nil {
+		return nil, &conductor.NotFoundError{Op: "Server", Kind: "operating table", Id: id}
+	}
+	ot, err := s.repo.GetOperatingTable(id)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := ot.Current(ta)
+	if err != nil {
+		return nil, err
+	}
+	resp := &TableResponse{Table: id, Ta: ta, Current: cur}
+	for _, item := range ot.Items() {
+		c, err := item.Current(ta)
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, *itemResponse(item, ta, c))
+	}
+	return resp, nil
+}
+
+func (s *Server) openapi(r *http.Request) (interface{}, error) {
+	return json.RawMessage(OPENAPI), nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// decodeCalc Decodes a CalcRequest checking required fields and returns a *CurrentCalc for
+// its conductor and conditions
+func (s *Server) decodeCalc(r *http.Request, required ...string) (*CalcRequest,
+	*conductor.CurrentCalc, error) {
+	var req CalcRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		var merr *http.MaxBytesError
+		if errors.As(err, &merr) {
+			return nil, nil, &statusError{http.StatusRequestEntityTooLarge, CODE_BODY_TOO_LARGE,
+				"body larger than " + strconv.FormatInt(merr.Limit, 10) + " bytes"}
+		}
+		return nil, nil, badRequest("", "invalid JSON body: "+err.Error())
+	}
+	if dec.More() {
+		return nil, nil, badRequest("", "invalid JSON body: more than one value")
+	}
+	for _, f := range required {
+		var missing bool
+		switch f {
+		case "ta":
+			missing = req.Ta == nil
+		case "tc":
+			missing = req.Tc == nil
+		case "ic":
+			missing = req.Ic == nil
+		case "tempMaxOp":
+			missing = req.TempMaxOp == nil
+		case "nsc":
+			missing = req.Nsc == nil
+		}
+		if missing {
+			return nil, nil, badRequest(f, f+" is required")
+		}
+	}
+
+	c, err := s.conductor(req.Conductor)
+	if err != nil {
+		return nil, nil, err
+	}
+	cc, err := conductor.NewCurrentCalc(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(req.Conditions) > 0 {
+		cond := conductor.CONDITIONS_DEFAULT
+		if err := decodeStrict(req.Conditions, &cond); err != nil {
+			return nil, nil, badRequest("conditions", "invalid conditions: "+err.Error())
+		}
+		if err := cc.SetConditions(cond); err != nil {
+			return nil, nil, err
+		}
+	}
+	if req.AirVelocityMs != nil {
+		if err := cc.SetAirVelocityIn(*req.AirVelocityMs, conductor.UN_M_S); err != nil {
+			return nil, nil, err
+		}
+	}
+	if req.Formula != "" {
+		m, err := conductor.GetModel(req.Formula)
+		if err != nil {
+			return nil, nil, badRequest("formula", "formula must be one of "+
+				strings.Join(conductor.ModelNames(), ", "))
+		}
+		cc.SetModel(m)
+	}
+	if req.DeltaTemp != nil {
+		if err := cc.SetDeltaTemp(*req.DeltaTemp); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &req, cc, nil
+}
+
+// conductor Returns conductor of a request: an id in the repository or a
+// conductor.ConductorDoc object whose category is an id in the repository or an object.
+// Conductors included in the request must pass Conductor.Validate.
+func (s *Server) conductor(raw json.RawMessage) (*conductor.Conductor, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, badRequest("conductor", "conductor is required")
+	}
+	var id string
+	if json.Unmarshal(raw, &id) == nil {
+		if s.repo == nil {
+			return nil, &conductor.NotFoundError{Op: "Server", Kind: "conductor", Id: id}
+		}
+		return s.repo.GetConductor(id)
+	}
+	var d conductor.ConductorDoc
+	if err := decodeStrict(raw, &d); err != nil {
+		return nil, badRequest("conductor", "conductor must be an id or an object: "+err.Error())
+	}
+	c, err := d.Conductor(func(id string) (*conductor.Category, error) {
+		if s.repo == nil {
+			return nil, &conductor.NotFoundError{Op: "Server", Kind: "category", Id: id}
+		}
+		return s.repo.GetCategory(id)
+	})
+	var cerr *conductor.ConfigError
+	if errors.As(err, &cerr) {
+		return nil, badRequest("conductor."+cerr.Field, "conductor."+cerr.Field+" "+cerr.Msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// decodeStrict Decodes raw into v rejecting unknown fields
+func decodeStrict(raw json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// itemResponse Returns the response for current cur of item at ta
+func itemResponse(item *conductor.OperatingItem, ta float64, cur float64) *CalcResponse {
+	nsc := item.Nsc()
+	phase := cur * float64(nsc)
+	return &CalcResponse{Conductor: conductorId(item.CurrentCalc().Conductor()), Ta: ta,
+		Tc: item.TempMaxOp(), Current: cur, Nsc: &nsc, Phase: &phase}
+}
+
+// conductorId Returns id of c or its name if id is empty
+func conductorId(c *conductor.Conductor) string {
+	if c.Id() != "" {
+		return c.Id()
+	}
+	return c.Name()
+}
@@ -0,0 +1,207 @@
+// Copyright Cristian Echeverría Rabí
+
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cer1969/go-conductor"
+)
+
+func getServer() *Server {
+	repo := conductor.NewMemoryRepository()
+	repo.PutCategory(conductor.CC_AAAC)
+	c := conductor.NewConductor("AAAC 740,8 MCM FLINT", conductor.CC_AAAC, 25.17, 375.4, 1.035,
+		11250, 0.08936, 1e-10, "FLINT")
+	repo.PutConductor(c)
+	cc, _ := conductor.NewCurrentCalc(c)
+	item1, _ := conductor.NewOperatingItem(cc, 50, 1)
+	item2, _ := conductor.NewOperatingItem(cc, 75, 2)
+	ot, _ := conductor.NewOperatingTable([]*conductor.OperatingItem{item1, item2}, "LINE 1")
+	repo.PutOperatingTable(ot)
+	return New(repo)
+}
+
+// do Sends request and decodes the response into v. Returns the status.
+func do(t *testing.T, s *Server, method string, path string, body string, v interface{}) int {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: application/json expected got %s", method, path, ct)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	return w.Code
+}
+
+type errorResponseBody struct {
+	Error ErrorBody
+}
+
+func Test_Current(t *testing.T) {
+	s := getServer()
+	var resp CalcResponse
+	status := do(t, s, "POST", "/v1/current", `{"conductor": "FLINT", "ta": 25, "tc": 50}`, &resp)
+	if status != http.StatusOK {
+		t.Fatalf("200 expected got %d", status)
+	}
+	if resp.Conductor != "FLINT" || math.Abs(resp.Current-517.7) > 0.1 {
+		t.Errorf("Current 517.7 expected got %+v", resp)
+	}
+
+	// Inline conductor and conditions
+	body := `{"conductor": {"name": "X", "category": {"name": "AAAC", "modelas": 6450,
+		"coefexp": 0.000023, "alpha": 0.0034}, "diameter": 25.17, "area": 375.4,
+		"weight": 1.035, "strength": 11250, "r25": 0.08936}, "conditions": {"airVelocity": 0},
+		"ta": 25, "tc": 50}`
+	status = do(t, s, "POST", "/v1/current", body, &resp)
+	if status != http.StatusOK || resp.Conductor != "X" || resp.Current >= 517.7 {
+		t.Errorf("Current < 517.7 expected got %d %+v", status, resp)
+	}
+
+	// Wind in m/s replaces conditions.airVelocity: 0.6096 m/s = 2 ft/s
+	body = `{"conductor": "FLINT", "conditions": {"airVelocity": 0}, "airVelocityMs": 0.6096,
+		"ta": 25, "tc": 50}`
+	status = do(t, s, "POST", "/v1/current", body, &resp)
+	if status != http.StatusOK || math.Abs(resp.Current-517.7) > 0.1 {
+		t.Errorf("Current 517.7 expected got %d %+v", status, resp)
+	}
+}
+
+func Test_TcTa(t *testing.T) {
+	s := getServer()
+	var resp CalcResponse
+	status := do(t, s, "POST", "/v1/tc", `{"conductor": "FLINT", "ta": 25, "ic": 517.7}`, &resp)
+	if status != http.StatusOK || math.Abs(resp.Tc-50) > 0.01 || resp.Iterations == nil {
+		t.Errorf("Tc 50 expected got %d %+v", status, resp)
+	}
+	status = do(t, s, "POST", "/v1/ta", `{"conductor": "FLINT", "tc": 50, "ic": 517.7}`, &resp)
+	if status != http.StatusOK || math.Abs(resp.Ta-25) > 0.01 {
+		t.Errorf("Ta 25 expected got %d %+v", status, resp)
+	}
+}
+
+func Test_OperatingItem(t *testing.T) {
+	s := getServer()
+	var resp CalcResponse
+	body := `{"conductor": "FLINT", "ta": 25, "tempMaxOp": 50, "nsc": 2}`
+	status := do(t, s, "POST", "/v1/operating-item/current", body, &resp)
+	if status != http.StatusOK || resp.Nsc == nil || *resp.Nsc != 2 || resp.Phase == nil ||
+		*resp.Phase != 2*resp.Current {
+		t.Errorf("Phase current expected got %d %+v", status, resp)
+	}
+}
+
+func Test_Tables(t *testing.T) {
+	s := getServer()
+	var list TablesResponse
+	if status := do(t, s, "GET", "/v1/tables", "", &list); status != http.StatusOK ||
+		len(list.Tables) != 1 || list.Tables[0] != "LINE 1" {
+		t.Errorf("LINE 1 expected got %+v", list)
+	}
+
+	var resp TableResponse
+	status := do(t, s, "GET", "/v1/tables/LINE%201/current?ta=25", "", &resp)
+	if status != http.StatusOK || len(resp.Items) != 2 {
+		t.Fatalf("2 items expected got %d %+v", status, resp)
+	}
+	if resp.Current != resp.Items[0].Current || math.Abs(resp.Current-517.7) > 0.1 {
+		t.Errorf("Current 517.7 expected got %+v", resp)
+	}
+
+	var e errorResponseBody
+	if status := do(t, s, "GET", "/v1/tables/NONE/current?ta=25", "", &e); status !=
+		http.StatusNotFound || e.Error.Code != CODE_NOT_FOUND {
+		t.Errorf("404 NOT_FOUND expected got %d %+v", status, e)
+	}
+	if status := do(t, s, "GET", "/v1/tables/LINE%201/current", "", &e); status !=
+		http.StatusBadRequest || e.Error.Field != "ta" {
+		t.Errorf("400 BAD_REQUEST expected got %d %+v", status, e)
+	}
+}
+
+func Test_Errors(t *testing.T) {
+	s := getServer()
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+		field  string
+	}{
+		{"GET", "/v1/current", "", 405, CODE_METHOD_NOT_ALLOWED, ""},
+		{"GET", "/v2/none", "", 404, CODE_NOT_FOUND, ""},
+		{"POST", "/v1/current", `{"conductor": "FLINT", "ta": 25`, 400, CODE_BAD_REQUEST, ""},
+		{"POST", "/v1/current", `{"conductor": "FLINT", "ta": 25, "x": 1}`, 400,
+			CODE_BAD_REQUEST, ""},
+		{"POST", "/v1/current", `{"conductor": "FLINT", "ta": 25}`, 400, CODE_BAD_REQUEST, "tc"},
+		{"POST", "/v1/current", `{"ta": 25, "tc": 50}`, 400, CODE_BAD_REQUEST, "conductor"},
+		{"POST", "/v1/current", `{"conductor": "NONE", "ta": 25, "tc": 50}`, 404,
+			CODE_NOT_FOUND, ""},
+		{"POST", "/v1/current", `{"conductor": "FLINT", "ta": 95, "tc": 50}`, 400,
+			CODE_OUT_OF_RANGE, "ta"},
+		{"POST", "/v1/current", `{"conductor": "FLINT", "ta": 25, "tc": 50, "conditions":
+			{"sunEffect": 2}}`, 400, CODE_OUT_OF_RANGE, "se"},
+		{"POST", "/v1/current", `{"conductor": "FLINT", "ta": 25, "tc": 50, "formula": "X"}`,
+			400, CODE_BAD_REQUEST, "formula"},
+		{"POST", "/v1/current", `{"conductor": {"name": "X", "category": "AAAC",
+			"diameter": 25.17, "r25": 0.1}, "ta": 25, "tc": 50}`, 400, CODE_VALIDATION, ""},
+		{"POST", "/v1/tc", `{"conductor": "FLINT", "ta": 25, "ic": 1e9}`, 400,
+			CODE_OUT_OF_RANGE, "ic"},
+		{"POST", "/v1/current", `{"conductor": "` + strings.Repeat("X", MAX_BODY) + `"}`, 413,
+			CODE_BODY_TOO_LARGE, ""},
+	}
+	for _, x := range tests {
+		var e errorResponseBody
+		status := do(t, s, x.method, x.path, x.body, &e)
+		if status != x.status || e.Error.Code != x.code || e.Error.Field != x.field {
+			t.Errorf("%s %s %s: %d %s %s expected got %d %+v", x.method, x.path, x.body,
+				x.status, x.code, x.field, status, e.Error)
+		}
+	}
+
+	var e errorResponseBody
+	do(t, s, "POST", "/v1/current", `{"conductor": {"name": "X", "category": "AAAC",
+		"diameter": 25.17, "area": 0, "weight": -1, "strength": 11250, "r25": 0.1}, "ta": 25,
+		"tc": 50}`, &e)
+	fields := map[string]bool{}
+	for _, v := range e.Error.Violations {
+		fields[v.Field] = true
+	}
+	if e.Error.Op != "Conductor.Validate" || !fields["area"] || !fields["weight"] {
+		t.Errorf("Violations of area and weight expected got %+v", e.Error)
+	}
+
+	e = errorResponseBody{}
+	do(t, s, "POST", "/v1/current", `{"conductor": "FLINT", "ta": 95, "tc": 50}`, &e)
+	if e.Error.Limit != "TA_MAX" || e.Error.Bound == nil || *e.Error.Bound != 90 ||
+		e.Error.Value == nil || *e.Error.Value != 95 {
+		t.Errorf("Range details expected got %+v", e.Error)
+	}
+}
+
+func Test_OpenAPI(t *testing.T) {
+	s := getServer()
+	var doc struct {
+		Openapi string
+		Paths   map[string]map[string]interface{}
+	}
+	if status := do(t, s, "GET", "/openapi.json", "", &doc); status != http.StatusOK {
+		t.Fatalf("200 expected got %d", status)
+	}
+	for path, method := range map[string]string{"/v1/current": "post", "/v1/tc": "post",
+		"/v1/ta": "post", "/v1/operating-item/current": "post", "/v1/tables": "get",
+		"/v1/tables/{id}/current": "get", "/openapi.json": "get"} {
+		if _, ok := doc.Paths[path][method]; !ok {
+			t.Errorf("%s %s not described", method, path)
+		}
+	}
+}
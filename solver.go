@@ -0,0 +1,85 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// brent Returns a root of f in bracket [a, b] using Brent's method (inverse quadratic
+// interpolation, secant and bisection steps). fa = f(a) and fb = f(b) must have opposite
+// signs. The root is located within tol; with positive = true the end of the final
+// bracket with f >= 0 is returned, so the caller chooses the side of the root by the sign
+// of f. Returns the number of evaluations of f and ok = false if the root was not located
+// after iterMax evaluations.
+func brent(f func(float64) float64, a float64, b float64, fa float64, fb float64, tol float64,
+	iterMax int, positive bool) (x float64, iter int, ok bool) {
+	const eps = 2.220446049250313e-16
+	c, fc := b, fb
+	var d, e float64
+	for iter = 0; iter < iterMax; {
+		if (fb > 0 && fc > 0) || (fb < 0 && fc < 0) {
+			// Root between a and b: c takes the place of a
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol1 := 2*eps*math.Abs(b) + 0.5*tol
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol1 || fb == 0 {
+			if positive && fb < 0 {
+				return c, iter, true
+			}
+			return b, iter, true
+		}
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			// Interpolation
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// Secant
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				// Inverse quadratic
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = m
+			}
+		} else {
+			// Bisection
+			d = m
+			e = m
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else if m > 0 {
+			b += tol1
+		} else {
+			b -= tol1
+		}
+		fb = f(b)
+		iter++
+	}
+	return b, iter, false
+}
@@ -0,0 +1,279 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+	"strconv"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Wire shapes of a StrandLayer
+const (
+	WS_ROUND       = 0 // Round wires
+	WS_TRAPEZOIDAL = 1 // Trapezoidal (shaped) wires
+)
+
+// StrandLayer Layer of wires of a Stranding. Layers are ordered from the center out; the
+// first layer is the center wire (or center wires laid together).
+type StrandLayer struct {
+	Wires     int       // Number of wires of the layer
+	Diameter  float64   // Wire diameter [mm], equivalent round diameter of trapezoidal wires
+	Material  *Material // *Material of the wires
+	LayRatio  float64   // Lay length / mean diameter of the layer (0 for straight wires)
+	Shape     int       // WS_ROUND or WS_TRAPEZOIDAL
+	Thickness float64   // Radial thickness of a trapezoidal layer [mm]
+}
+
+// wireArea Returns the cross section area of one wire [mm2]
+func (sl *StrandLayer) wireArea() float64 {
+	return math.Pi * sl.Diameter * sl.Diameter / 4
+}
+
+// area Returns the cross section area of the layer [mm2]
+func (sl *StrandLayer) area() float64 {
+	return float64(sl.Wires) * sl.wireArea()
+}
+
+// layFactor Returns the length of a wire per unit length of conductor
+func (sl *StrandLayer) layFactor() float64 {
+	if sl.LayRatio == 0 {
+		return 1
+	}
+	x := math.Pi / sl.LayRatio
+	return math.Sqrt(1 + x*x)
+}
+
+// depth Returns the increment of the overall diameter due to the layer [mm]
+func (sl *StrandLayer) depth(first bool) float64 {
+	switch {
+	case sl.Shape == WS_TRAPEZOIDAL:
+		return 2 * sl.Thickness
+	case first && sl.Wires > 1:
+		// Circumscribed diameter of n wires laid together
+		return sl.Diameter * (1 + 1/math.Sin(math.Pi/float64(sl.Wires)))
+	case first:
+		return sl.Diameter
+	default:
+		return 2 * sl.Diameter
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+// NewStranding Returns *Stranding object
+// name   string        : Name of conductor
+// layers []StrandLayer : Layers from the center out (at least one, one conducting)
+// id     string        : Database id
+func NewStranding(name string, layers []StrandLayer, id string) (*Stranding, error) {
+	if len(layers) < 1 {
+		return nil, &ConfigError{"NewStranding", "len(layers)", "< 1"}
+	}
+	for i, sl := range layers {
+		field := "layer " + strconv.Itoa(i)
+		if sl.Wires < 1 {
+			return nil, &RangeError{"NewStranding", field + " Wires", "<", "1", 1,
+				float64(sl.Wires)}
+		}
+		if sl.Diameter <= 0 {
+			return nil, &RangeError{"NewStranding", field + " Diameter", "<=", "0", 0, sl.Diameter}
+		}
+		if sl.Material == nil {
+			return nil, &ConfigError{"NewStranding", field + " Material", "== nil"}
+		}
+		if sl.LayRatio < 0 {
+			return nil, &RangeError{"NewStranding", field + " LayRatio", "<", "0", 0, sl.LayRatio}
+		}
+		switch sl.Shape {
+		case WS_ROUND:
+		case WS_TRAPEZOIDAL:
+			if sl.Thickness <= 0 {
+				return nil, &RangeError{"NewStranding", field + " Thickness", "<=", "0", 0,
+					sl.Thickness}
+			}
+		default:
+			return nil, &ConfigError{"NewStranding", field + " Shape",
+				"must be WS_ROUND or WS_TRAPEZOIDAL"}
+		}
+	}
+	list := make([]StrandLayer, len(layers))
+	copy(list, layers)
+	st := &Stranding{name, list, id}
+	if g := 1 / st.Resistance(20); !(g > 0) {
+		// Resistance +Inf and Alpha NaN without conducting layers
+		return nil, &RangeError{"NewStranding", "conductance of layers", "<=", "0", 0, g}
+	}
+	return st, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// Stranding Description of a stranded conductor by layers of wires. Derives the
+// parameters of Conductor and Category from the geometry and the materials of the
+// wires. Layers of core materials (Material.Core) form the steel core.
+type Stranding struct {
+	name   string        // Name of conductor
+	layers []StrandLayer // Layers from the center out
+	id     string        // Database id
+}
+
+// Diameter Returns the overall diameter [mm]
+func (s *Stranding) Diameter() float64 {
+	d := 0.0
+	for i := range s.layers {
+		d += s.layers[i].depth(i == 0)
+	}
+	return d
+}
+
+// Area Returns the total cross section area [mm2]
+func (s *Stranding) Area() float64 {
+	return s.AluminiumArea() + s.SteelArea()
+}
+
+// AluminiumArea Returns the cross section area of layers not in the core [mm2]
+func (s *Stranding) AluminiumArea() float64 {
+	total := 0.0
+	for i := range s.layers {
+		if !s.layers[i].Material.Core {
+			total += s.layers[i].area()
+		}
+	}
+	return total
+}
+
+// SteelArea Returns the cross section area of core layers [mm2]
+func (s *Stranding) SteelArea() float64 {
+	total := 0.0
+	for i := range s.layers {
+		if s.layers[i].Material.Core {
+			total += s.layers[i].area()
+		}
+	}
+	return total
+}
+
+// Weight Returns the weight per unit including the lay factor of each layer [kg/m]
+func (s *Stranding) Weight() float64 {
+	total := 0.0
+	for i := range s.layers {
+		sl := &s.layers[i]
+		total += sl.area() * sl.layFactor() * sl.Material.Density / 1000
+	}
+	return total
+}
+
+// Strength Returns the rated strength as the sum of the strength of the wires [kg]
+func (s *Stranding) Strength() float64 {
+	total := 0.0
+	for i := range s.layers {
+		total += s.layers[i].area() * s.layers[i].Material.Strength
+	}
+	return total
+}
+
+// Resistance Returns the DC resistance at temperature t [Ohm/km] with every layer in
+// parallel and the lay factor of each layer
+func (s *Stranding) Resistance(t float64) float64 {
+	g := 0.0 // Conductance [S*km]
+	for i := range s.layers {
+		sl := &s.layers[i]
+		g += sl.Material.conductance(sl.area(), sl.layFactor(), t)
+	}
+	return 1 / g
+}
+
+// Alpha Returns the temperature coefficient of resistance between 25°C and 75°C [1/°C]
+func (s *Stranding) Alpha() float64 {
+	r25 := s.Resistance(25)
+	return (s.Resistance(75) - r25) / (50 * r25)
+}
+
+// Hcap Returns the heat capacity [kcal/(ft*°C)]
+func (s *Stranding) Hcap() float64 {
+	total := 0.0 // [J/(m*°C)]
+	for i := range s.layers {
+		sl := &s.layers[i]
+		total += sl.area() * sl.layFactor() * sl.Material.Density / 1000 * sl.Material.SpecificHeat
+	}
+	return total * foot / 1000 / kcal
+}
+
+// Modelas Returns the composite modulus of elasticity weighted by area [kg/mm2]
+func (s *Stranding) Modelas() float64 {
+	ea := 0.0
+	for i := range s.layers {
+		ea += s.layers[i].area() * s.layers[i].Material.Modelas
+	}
+	return ea / s.Area()
+}
+
+// Coefexp Returns the composite coefficient of thermal expansion weighted by area and
+// modulus of elasticity [1/°C]
+func (s *Stranding) Coefexp() float64 {
+	ea, eaa := 0.0, 0.0
+	for i := range s.layers {
+		m := s.layers[i].Material
+		x := s.layers[i].area() * m.Modelas
+		ea += x
+		eaa += x * m.Coefexp
+	}
+	return eaa / ea
+}
+
+// Composition Returns the materials of the stranding by area fraction. Layers of the
+// same material are added. Returns an error without conducting materials.
+func (s *Stranding) Composition() (*Composition, error) {
+	area := s.Area()
+	var parts []MaterialPart
+	index := map[*Material]int{}
+	for i := range s.layers {
+		sl := &s.layers[i]
+		j, ok := index[sl.Material]
+		if !ok {
+			j = len(parts)
+			index[sl.Material] = j
+			parts = append(parts, MaterialPart{sl.Material, 0})
+		}
+		parts[j].Fraction += sl.area() / area
+	}
+	co, err := NewComposition(parts)
+	if err != nil {
+		return nil, &OpError{"Stranding.Composition", err}
+	}
+	return co, nil
+}
+
+// Category Returns *Category with the composite properties of the stranding
+// creep float64 : Creep °C
+func (s *Stranding) Category(creep float64) *Category {
+	return &Category{s.name, s.Modelas(), s.Coefexp(), creep, s.Alpha(), s.id}
+}
+
+// Conductor Returns *Conductor with the properties of the stranding
+// category *Category : *Category instance (nil uses s.Category(0))
+func (s *Stranding) Conductor(category *Category) *Conductor {
+	if category == nil {
+		category = s.Category(0)
+	}
+	return &Conductor{s.name, category, s.Diameter(), s.Area(), s.Weight(), s.Strength(),
+		s.Resistance(25), s.Hcap(), s.id}
+}
+
+// Layer Returns a copy of layer i
+func (s *Stranding) Layer(i int) StrandLayer {
+	return s.layers[i]
+}
+
+func (s *Stranding) Len() int {
+	return len(s.layers)
+}
+
+func (s *Stranding) Name() string {
+	return s.name
+}
+
+func (s *Stranding) Id() string {
+	return s.id
+}
@@ -0,0 +1,114 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// getStranding Returns ACSR 795 MCM DRAKE 26/7
+func getStranding() *Stranding {
+	s, _ := NewStranding("ACSR 795 MCM DRAKE", []StrandLayer{
+		{1, 3.454, MT_STEEL_GA, 0, WS_ROUND, 0},
+		{6, 3.454, MT_STEEL_GA, 19, WS_ROUND, 0},
+		{10, 4.442, MT_AL1350, 14, WS_ROUND, 0},
+		{16, 4.442, MT_AL1350, 11, WS_ROUND, 0},
+	}, "DRAKE")
+	return s
+}
+
+//----------------------------------------------------------------------------------------
+
+func Test_Stranding_Geometry(t *testing.T) {
+	s := getStranding()
+	tests := []struct {
+		name  string
+		value float64
+		want  float64
+		tol   float64
+	}{
+		{"Diameter", s.Diameter(), 28.13, 0.01},
+		{"AluminiumArea", s.AluminiumArea(), 402.9, 0.1},
+		{"SteelArea", s.SteelArea(), 65.6, 0.1},
+		{"Weight", s.Weight(), 1.628, 0.02},
+		{"Resistance", s.Resistance(20), 0.0717, 0.001},
+	}
+	for _, x := range tests {
+		if math.Abs(x.value-x.want) > x.tol {
+			t.Errorf("%s %f expected got %f", x.name, x.want, x.value)
+		}
+	}
+	if s.Resistance(75) <= s.Resistance(25) || s.Alpha() <= 0 || s.Alpha() > MT_AL1350.Alpha {
+		t.Errorf("Alpha 0 < alpha < %f expected got %f", MT_AL1350.Alpha, s.Alpha())
+	}
+	e := s.Modelas()
+	if e <= MT_AL1350.Modelas || e >= MT_STEEL_GA.Modelas {
+		t.Errorf("Composite modulus expected got %f", e)
+	}
+	a := s.Coefexp()
+	if a <= MT_STEEL_GA.Coefexp || a >= MT_AL1350.Coefexp {
+		t.Errorf("Composite expansion expected got %g", a)
+	}
+}
+
+func Test_Stranding_Conductor(t *testing.T) {
+	s := getStranding()
+	c := s.Conductor(nil)
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+	if c.Id() != "DRAKE" || c.Category().Id() != "DRAKE" || c.R25() != s.Resistance(25) ||
+		c.Hcap() != s.Hcap() {
+		t.Errorf("Stranding properties expected got %+v", c)
+	}
+	if c := s.Conductor(CC_ACSR); c.Category() != CC_ACSR {
+		t.Error("CC_ACSR expected")
+	}
+
+	// Trapezoidal layers are thinner than round wires of the same area
+	trap, err := NewStranding("TW", []StrandLayer{
+		{1, 3.454, MT_STEEL_GA, 0, WS_ROUND, 0},
+		{6, 3.454, MT_STEEL_GA, 19, WS_ROUND, 0},
+		{10, 4.442, MT_AL1350, 14, WS_TRAPEZOIDAL, 3.9},
+		{16, 4.442, MT_AL1350, 11, WS_TRAPEZOIDAL, 3.9},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trap.Area() != s.Area() || trap.Diameter() >= s.Diameter() {
+		t.Errorf("Smaller diameter expected got %f", trap.Diameter())
+	}
+}
+
+func Test_Stranding_Errors(t *testing.T) {
+	tests := [][]StrandLayer{
+		nil,
+		{{0, 3, MT_AL1350, 0, WS_ROUND, 0}},
+		{{1, 0, MT_AL1350, 0, WS_ROUND, 0}},
+		{{1, 3, nil, 0, WS_ROUND, 0}},
+		{{1, 3, MT_AL1350, -1, WS_ROUND, 0}},
+		{{1, 3, MT_AL1350, 0, WS_TRAPEZOIDAL, 0}},
+		{{1, 3, MT_AL1350, 0, 5, 0}},
+	}
+	for i, layers := range tests {
+		if _, err := NewStranding("X", layers, ""); !errors.Is(err, ErrInvalidConfig) &&
+			!errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%d: error expected got %v", i, err)
+		}
+	}
+	carbon := []StrandLayer{{7, 3, MT_CARBON, 0, WS_ROUND, 0}}
+	if _, err := NewStranding("X", carbon, ""); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range error without conducting layers expected got %v", err)
+	}
+}
+
+func Example_Stranding() {
+	s := getStranding()
+	c := s.Conductor(nil)
+	fmt.Printf("%s %.2f mm %.1f mm2 %.3f kg/m %.4f Ohm/km\n", c.Name(), c.Diameter(),
+		c.Area(), c.Weight(), c.R25())
+	// Output: ACSR 795 MCM DRAKE 28.13 mm 468.5 mm2 1.643 kg/m 0.0724 Ohm/km
+}
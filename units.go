@@ -0,0 +1,297 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"math"
+)
+
+//----------------------------------------------------------------------------------------
+
+// Dimension Physical dimension of a Unit. Only units with the same Dimension can be
+// converted between them.
+type Dimension string
+
+// Dimensions used by the library
+const (
+	DIM_LENGTH      Dimension = "LENGTH"      // Base unit mm
+	DIM_AREA        Dimension = "AREA"        // Base unit mm2
+	DIM_LINEAR_MASS Dimension = "LINEAR_MASS" // Base unit kg/m
+	DIM_FORCE       Dimension = "FORCE"       // Base unit kg (kilogram-force)
+	DIM_RESISTANCE  Dimension = "RESISTANCE"  // Base unit Ohm/km
+	DIM_HEAT_CAP    Dimension = "HEAT_CAP"    // Base unit kcal/(ft*°C)
+	DIM_VELOCITY    Dimension = "VELOCITY"    // Base unit ft/seg
+	DIM_TEMPERATURE Dimension = "TEMPERATURE" // Base unit °C
+	DIM_ALTITUDE    Dimension = "ALTITUDE"    // Base unit m
+	DIM_STRESS      Dimension = "STRESS"      // Base unit kg/mm2
+)
+
+//----------------------------------------------------------------------------------------
+
+// Unit Unit of measure. A value v expressed in the unit equals v*factor + offset in the
+// base unit of its Dimension, the unit used internally by the library.
+type Unit struct {
+	name   string    // Symbol of the unit
+	dim    Dimension // Physical dimension
+	factor float64   // Multiplier to base unit
+	offset float64   // Offset to base unit (temperatures only)
+}
+
+func (u Unit) Name() string {
+	return u.name
+}
+
+func (u Unit) Dimension() Dimension {
+	return u.dim
+}
+
+// ToBase Returns value v expressed in unit u converted to the base unit
+func (u Unit) ToBase(v float64) float64 {
+	return v*u.factor + u.offset
+}
+
+// FromBase Returns value v expressed in the base unit converted to unit u
+func (u Unit) FromBase(v float64) float64 {
+	return (v - u.offset) / u.factor
+}
+
+//----------------------------------------------------------------------------------------
+
+// Convert Returns v expressed in unit from converted to unit to
+// v    float64 : Value to convert
+// from Unit    : Unit of v
+// to   Unit    : Unit of returned value (required to.Dimension() == from.Dimension())
+func Convert(v float64, from Unit, to Unit) (float64, error) {
+	if from.dim != to.dim {
+		return 0, &ConfigError{"Convert", from.name + " to " + to.name, "dimension mismatch"}
+	}
+	return to.FromBase(from.ToBase(v)), nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// Conversion constants
+const (
+	inch       = 25.4       // mm
+	foot       = 304.8      // mm
+	mile       = 1.609344   // km
+	poundMass  = 0.45359237 // kg
+	gravity    = 9.80665    // N/kg
+	kcal       = 4186.8     // J
+	kcmilToMm2 = 0.506707479
+)
+
+// Units of measure. Units marked (base) are the ones used internally by the library.
+var (
+	UN_MM        = Unit{"mm", DIM_LENGTH, 1, 0} // (base)
+	UN_CM        = Unit{"cm", DIM_LENGTH, 10, 0}
+	UN_M         = Unit{"m", DIM_LENGTH, 1000, 0}
+	UN_IN        = Unit{"in", DIM_LENGTH, inch, 0}
+	UN_FT        = Unit{"ft", DIM_LENGTH, foot, 0}
+	UN_MM2       = Unit{"mm2", DIM_AREA, 1, 0} // (base)
+	UN_M2        = Unit{"m2", DIM_AREA, 1e6, 0}
+	UN_IN2       = Unit{"in2", DIM_AREA, inch * inch, 0}
+	UN_KCM       = Unit{"kcmil", DIM_AREA, kcmilToMm2, 0}
+	UN_KG_M      = Unit{"kg/m", DIM_LINEAR_MASS, 1, 0} // (base)
+	UN_KG_KM     = Unit{"kg/km", DIM_LINEAR_MASS, 0.001, 0}
+	UN_LB_FT     = Unit{"lb/ft", DIM_LINEAR_MASS, poundMass / (foot / 1000), 0}
+	UN_KGF       = Unit{"kg", DIM_FORCE, 1, 0} // (base)
+	UN_N         = Unit{"N", DIM_FORCE, 1 / gravity, 0}
+	UN_KN        = Unit{"kN", DIM_FORCE, 1000 / gravity, 0}
+	UN_LBF       = Unit{"lbf", DIM_FORCE, poundMass, 0}
+	UN_OHM_KM    = Unit{"Ohm/km", DIM_RESISTANCE, 1, 0} // (base)
+	UN_OHM_M     = Unit{"Ohm/m", DIM_RESISTANCE, 1000, 0}
+	UN_OHM_MI    = Unit{"Ohm/mi", DIM_RESISTANCE, 1 / mile, 0}
+	UN_OHM_FT    = Unit{"Ohm/ft", DIM_RESISTANCE, 1e6 / foot, 0}
+	UN_KCAL_FT_C = Unit{"kcal/(ft*°C)", DIM_HEAT_CAP, 1, 0} // (base)
+	UN_J_M_K     = Unit{"J/(m*K)", DIM_HEAT_CAP, (foot / 1000) / kcal, 0}
+	UN_WS_FT_C   = Unit{"W*s/(ft*°C)", DIM_HEAT_CAP, 1 / kcal, 0}
+	UN_FT_S      = Unit{"ft/s", DIM_VELOCITY, 1, 0} // (base)
+	UN_M_S       = Unit{"m/s", DIM_VELOCITY, 1000 / foot, 0}
+	UN_KM_H      = Unit{"km/h", DIM_VELOCITY, 1e6 / foot / 3600, 0}
+	UN_MPH       = Unit{"mph", DIM_VELOCITY, mile * 1e6 / foot / 3600, 0}
+	UN_KNOT      = Unit{"kn", DIM_VELOCITY, 1852000 / foot / 3600, 0}
+	UN_C         = Unit{"°C", DIM_TEMPERATURE, 1, 0} // (base)
+	UN_K         = Unit{"K", DIM_TEMPERATURE, 1, -273.15}
+	UN_F         = Unit{"°F", DIM_TEMPERATURE, 5.0 / 9.0, -32 * 5.0 / 9.0}
+	UN_ALT_M     = Unit{"m", DIM_ALTITUDE, 1, 0} // (base)
+	UN_ALT_FT    = Unit{"ft", DIM_ALTITUDE, foot / 1000, 0}
+	UN_KG_MM2    = Unit{"kg/mm2", DIM_STRESS, 1, 0} // (base)
+	UN_MPA       = Unit{"MPa", DIM_STRESS, 1 / gravity, 0}
+	UN_GPA       = Unit{"GPa", DIM_STRESS, 1000 / gravity, 0}
+	UN_PSI       = Unit{"psi", DIM_STRESS, poundMass / (inch * inch), 0}
+)
+
+//----------------------------------------------------------------------------------------
+
+// UnitSystem Units used to enter and read values of a Conductor, Category and CurrentCalc
+type UnitSystem struct {
+	Length      Unit // Diameter
+	Area        Unit // Cross section area
+	LinearMass  Unit // Weight per unit
+	Force       Unit // Rated strength and tensions
+	Resistance  Unit // Resistance per unit
+	HeatCap     Unit // Heat capacity
+	Velocity    Unit // Velocity of air stream
+	Temperature Unit // Temperatures
+	Altitude    Unit // Altitude
+	Stress      Unit // Modulus of elasticity
+}
+
+// Predefined unit systems
+//
+//	US_BASE     : Units used internally by the library (mm, mm2, kg/m, kg, Ohm/km, kcal/(ft*°C),
+//	              ft/s, °C, m, kg/mm2)
+//	US_SI       : SI units (mm, mm2, kg/m, N, Ohm/km, J/(m*K), m/s, °C, m, MPa)
+//	US_IMPERIAL : Imperial units (in, kcmil, lb/ft, lbf, Ohm/mi, W*s/(ft*°C), ft/s, °F, ft, psi)
+var (
+	US_BASE = UnitSystem{UN_MM, UN_MM2, UN_KG_M, UN_KGF, UN_OHM_KM, UN_KCAL_FT_C, UN_FT_S, UN_C,
+		UN_ALT_M, UN_KG_MM2}
+	US_SI = UnitSystem{UN_MM, UN_MM2, UN_KG_M, UN_N, UN_OHM_KM, UN_J_M_K, UN_M_S, UN_C, UN_ALT_M,
+		UN_MPA}
+	US_IMPERIAL = UnitSystem{UN_IN, UN_KCM, UN_LB_FT, UN_LBF, UN_OHM_MI, UN_WS_FT_C, UN_FT_S, UN_F,
+		UN_ALT_FT, UN_PSI}
+)
+
+// Check Returns error if any Unit of us has a wrong Dimension
+func (us *UnitSystem) Check() error {
+	units := []struct {
+		u   Unit
+		dim Dimension
+	}{
+		{us.Length, DIM_LENGTH}, {us.Area, DIM_AREA}, {us.LinearMass, DIM_LINEAR_MASS},
+		{us.Force, DIM_FORCE}, {us.Resistance, DIM_RESISTANCE}, {us.HeatCap, DIM_HEAT_CAP},
+		{us.Velocity, DIM_VELOCITY}, {us.Temperature, DIM_TEMPERATURE},
+		{us.Altitude, DIM_ALTITUDE}, {us.Stress, DIM_STRESS},
+	}
+	for _, x := range units {
+		if x.u.dim != x.dim {
+			return &ConfigError{"UnitSystem.Check", x.u.name, "is not " + string(x.dim)}
+		}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// GetIn Returns *Conductor from attributes values expressed in unit system us
+func (ca *ConductorMaker) GetIn(us UnitSystem) (*Conductor, error) {
+	if err := us.Check(); err != nil {
+		return nil, err
+	}
+	return &Conductor{ca.Name, ca.Category, us.Length.ToBase(ca.Diameter), us.Area.ToBase(ca.Area),
+		us.LinearMass.ToBase(ca.Weight), us.Force.ToBase(ca.Strength),
+		us.Resistance.ToBase(ca.R25), us.HeatCap.ToBase(ca.Hcap), ca.Id}, nil
+}
+
+// MakerIn Returns ConductorMaker with attributes values expressed in unit system us
+func (c *Conductor) MakerIn(us UnitSystem) (ConductorMaker, error) {
+	if err := us.Check(); err != nil {
+		return ConductorMaker{}, err
+	}
+	return ConductorMaker{c.name, c.category, us.Length.FromBase(c.diameter),
+		us.Area.FromBase(c.area), us.LinearMass.FromBase(c.weight), us.Force.FromBase(c.strength),
+		us.Resistance.FromBase(c.r25), us.HeatCap.FromBase(c.hcap), c.id}, nil
+}
+
+// GetIn Returns *Category from attributes values expressed in unit system us. Modelas is
+// expressed in us.Stress. Coefexp, Creep and Alpha are per °C or °C.
+func (ca *CategoryMaker) GetIn(us UnitSystem) (*Category, error) {
+	if err := us.Check(); err != nil {
+		return nil, err
+	}
+	return &Category{ca.Name, us.Stress.ToBase(ca.Modelas), ca.Coefexp, ca.Creep, ca.Alpha, ca.Id},
+		nil
+}
+
+// MakerIn Returns CategoryMaker with attributes values expressed in unit system us
+func (cat *Category) MakerIn(us UnitSystem) (CategoryMaker, error) {
+	if err := us.Check(); err != nil {
+		return CategoryMaker{}, err
+	}
+	return CategoryMaker{cat.name, us.Stress.FromBase(cat.modelas), cat.coefexp, cat.creep,
+		cat.alpha, cat.id}, nil
+}
+
+//----------------------------------------------------------------------------------------
+
+// AirVelocityIn Returns velocity of air stream expressed in unit u
+func (cc *CurrentCalc) AirVelocityIn(u Unit) (float64, error) {
+	return Convert(cc.airVelocity, UN_FT_S, u)
+}
+
+// SetAirVelocityIn Sets velocity of air stream v expressed in unit u (e.g. UN_M_S)
+func (cc *CurrentCalc) SetAirVelocityIn(v float64, u Unit) error {
+	x, err := Convert(v, u, UN_FT_S)
+	if err != nil {
+		return &OpError{"CurrentCalc.SetAirVelocityIn", err}
+	}
+	return cc.SetAirVelocity(x)
+}
+
+// AltitudeIn Returns altitude expressed in unit u
+func (cc *CurrentCalc) AltitudeIn(u Unit) (float64, error) {
+	return Convert(cc.altitude, UN_ALT_M, u)
+}
+
+// SetAltitudeIn Sets altitude h expressed in unit u (e.g. UN_ALT_FT)
+func (cc *CurrentCalc) SetAltitudeIn(h float64, u Unit) error {
+	x, err := Convert(h, u, UN_ALT_M)
+	if err != nil {
+		return &OpError{"CurrentCalc.SetAltitudeIn", err}
+	}
+	return cc.SetAltitude(x)
+}
+
+// ResistanceIn Returns resistance at temperature tc expressed in unit ut, in resistance
+// unit ur
+func (cc *CurrentCalc) ResistanceIn(tc float64, ut Unit, ur Unit) (float64, error) {
+	t, err := Convert(tc, ut, UN_C)
+	if err != nil {
+		return math.NaN(), &OpError{"CurrentCalc.ResistanceIn", err}
+	}
+	r, err := cc.Resistance(t)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return Convert(r, UN_OHM_KM, ur)
+}
+
+// CurrentIn Returns current [A] for ambient temperature ta and conductor temperature tc,
+// both expressed in temperature unit ut
+func (cc *CurrentCalc) CurrentIn(ta float64, tc float64, ut Unit) (float64, error) {
+	a, err := Convert(ta, ut, UN_C)
+	if err != nil {
+		return math.NaN(), &OpError{"CurrentCalc.CurrentIn", err}
+	}
+	c, _ := Convert(tc, ut, UN_C)
+	return cc.Current(a, c)
+}
+
+// TcIn Returns conductor temperature in unit ut for ambient temperature ta in unit ut and
+// current ic [A]
+func (cc *CurrentCalc) TcIn(ta float64, ic float64, ut Unit) (float64, error) {
+	a, err := Convert(ta, ut, UN_C)
+	if err != nil {
+		return math.NaN(), &OpError{"CurrentCalc.TcIn", err}
+	}
+	tc, err := cc.Tc(a, ic)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return ut.FromBase(tc), nil
+}
+
+// TaIn Returns ambient temperature in unit ut for conductor temperature tc in unit ut and
+// current ic [A]
+func (cc *CurrentCalc) TaIn(tc float64, ic float64, ut Unit) (float64, error) {
+	c, err := Convert(tc, ut, UN_C)
+	if err != nil {
+		return math.NaN(), &OpError{"CurrentCalc.TaIn", err}
+	}
+	ta, err := cc.Ta(c, ic)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return ut.FromBase(ta), nil
+}
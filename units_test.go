@@ -0,0 +1,125 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func Test_Convert(t *testing.T) {
+	tests := []struct {
+		v    float64
+		from Unit
+		to   Unit
+		want float64
+	}{
+		{1, UN_IN, UN_MM, 25.4},
+		{1, UN_M_S, UN_FT_S, 3.280840},
+		{36, UN_KM_H, UN_M_S, 10},
+		{1, UN_KN, UN_KGF, 101.971621},
+		{1, UN_LB_FT, UN_KG_M, 1.488164},
+		{1, UN_OHM_MI, UN_OHM_KM, 0.621371},
+		{1000, UN_KCM, UN_MM2, 506.707479},
+		{212, UN_F, UN_C, 100},
+		{0, UN_C, UN_K, 273.15},
+		{1, UN_KCAL_FT_C, UN_J_M_K, 13736.220},
+		{1, UN_GPA, UN_KG_MM2, 101.971621},
+	}
+	for _, x := range tests {
+		got, err := Convert(x.v, x.from, x.to)
+		if err != nil {
+			t.Error(err)
+		}
+		if math.Abs(got-x.want) > 1e-6*math.Max(1, math.Abs(x.want)) {
+			t.Errorf("%v %s = %f %s expected got %f", x.v, x.from.Name(), x.want, x.to.Name(), got)
+		}
+	}
+	_, err := Convert(1, UN_M_S, UN_MM)
+	if err == nil {
+		t.Error("Dimension mismatch error expected")
+	}
+}
+
+func Test_UnitSystem_Check(t *testing.T) {
+	for _, us := range []UnitSystem{US_BASE, US_SI, US_IMPERIAL} {
+		if err := us.Check(); err != nil {
+			t.Error(err)
+		}
+	}
+	us := US_SI
+	us.Velocity = UN_MM
+	if err := us.Check(); err == nil {
+		t.Error("Wrong dimension error expected")
+	}
+}
+
+func Test_ConductorMaker_GetIn(t *testing.T) {
+	cmk := ConductorMaker{"AAAC 740,8 MCM FLINT", CC_AAAC, 0.991, 740.8, 0.6953, 24500, 0.1438,
+		1e-10, ""}
+	c, err := cmk.GetIn(US_IMPERIAL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(c.Diameter()-25.17) > 0.01 {
+		t.Errorf("Diameter 25.17 expected got %f", c.Diameter())
+	}
+	if math.Abs(c.Area()-375.4) > 0.1 {
+		t.Errorf("Area 375.4 expected got %f", c.Area())
+	}
+	if math.Abs(c.R25()-0.08936) > 0.0001 {
+		t.Errorf("R25 0.08936 expected got %f", c.R25())
+	}
+	back, _ := c.MakerIn(US_IMPERIAL)
+	if math.Abs(back.Diameter-cmk.Diameter) > 1e-9 || math.Abs(back.Weight-cmk.Weight) > 1e-9 {
+		t.Error("MakerIn must invert GetIn")
+	}
+}
+
+func Test_CurrentCalc_SetAirVelocityIn(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	err := cc.SetAirVelocityIn(0.6096, UN_M_S)
+	if err != nil {
+		t.Error(err)
+	}
+	if math.Abs(cc.AirVelocity()-2) > 1e-9 {
+		t.Errorf("AirVelocity 2 ft/s expected got %f", cc.AirVelocity())
+	}
+	v, _ := cc.AirVelocityIn(UN_M_S)
+	if math.Abs(v-0.6096) > 1e-9 {
+		t.Errorf("AirVelocity 0.6096 m/s expected got %f", v)
+	}
+	err = cc.SetAirVelocityIn(1, UN_MM)
+	if err == nil {
+		t.Error("Dimension mismatch error expected")
+	}
+	err = cc.SetAirVelocityIn(-1, UN_M_S)
+	if err == nil {
+		t.Error("AirVelocity<0 error expected")
+	}
+}
+
+func Test_CurrentCalc_TemperaturesIn(t *testing.T) {
+	cc, _ := NewCurrentCalc(getConductor())
+	x1, _ := cc.Current(25, 50)
+	x2, _ := cc.CurrentIn(77, 122, UN_F)
+	if math.Abs(x1-x2) > 1e-9 {
+		t.Errorf("Current %f expected got %f", x1, x2)
+	}
+	tc, _ := cc.TcIn(77, x1, UN_F)
+	if math.Abs(tc-122) > 2*cc.DeltaTemp()*1.8 {
+		t.Errorf("Tc 122 °F expected got %f", tc)
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Example_CurrentCalc_SetAirVelocityIn() {
+	cc, _ := NewCurrentCalc(getConductor())
+	cc.SetAirVelocityIn(1, UN_M_S)
+	cur, _ := cc.Current(25, 50)
+	fmt.Printf("%.2f ft/s - %.2f", cc.AirVelocity(), cur)
+	// Output:
+	// 3.28 ft/s - 601.93
+}
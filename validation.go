@@ -0,0 +1,292 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+//----------------------------------------------------------------------------------------
+
+// ValidationLimits Plausible ranges of Category and Conductor data for a material
+type ValidationLimits struct {
+	ModelasMin float64 // Minimum modulus of elasticity [kg/mm2]
+	ModelasMax float64 // Maximum modulus of elasticity [kg/mm2]
+	CoefexpMin float64 // Minimum coefficient of thermal expansion [1/°C]
+	CoefexpMax float64 // Maximum coefficient of thermal expansion [1/°C]
+	DensityMin float64 // Minimum apparent density weight/area [kg/dm3]
+	DensityMax float64 // Maximum apparent density weight/area [kg/dm3]
+}
+
+// VALIDATION_LIMITS Limits by Category id. Categories with other ids are checked with
+// VALIDATION_LIMITS_DEFAULT. The apparent density includes the stranding increment and,
+// for ACSR, the steel core.
+var VALIDATION_LIMITS = map[string]*ValidationLimits{
+	"CU":     {9000.0, 13500.0, 0.0000160, 0.0000180, 8.3, 9.4},
+	"AAAC":   {5500.0, 7000.0, 0.0000220, 0.0000240, 2.6, 2.9},
+	"ACAR":   {5500.0, 7000.0, 0.0000210, 0.0000260, 2.6, 2.9},
+	"ACSR":   {6000.0, 11000.0, 0.0000140, 0.0000220, 2.8, 5.5},
+	"AAC":    {5000.0, 6500.0, 0.0000220, 0.0000240, 2.6, 2.9},
+	"CUWELD": {13000.0, 20000.0, 0.0000120, 0.0000150, 7.5, 9.2},
+}
+
+// VALIDATION_LIMITS_DEFAULT Limits for categories without an entry in VALIDATION_LIMITS
+var VALIDATION_LIMITS_DEFAULT = &ValidationLimits{3000.0, 25000.0, 0.0000010, 0.0000300, 1.5,
+	9.5}
+
+// Limits of the fill factor area/(pi*diameter²/4). Round wire strands are near 0.75 and
+// compact or trapezoidal wire conductors are near 0.9.
+const (
+	FILL_FACTOR_MIN = 0.5
+	FILL_FACTOR_MAX = 1.0
+)
+
+//----------------------------------------------------------------------------------------
+
+// Violation A field value that does not pass validation
+type Violation struct {
+	Index int     // Position in the list for ValidateConductors and ValidateCategories
+	Id    string  // Id of the object (name if id is empty)
+	Field string  // Field name (category fields of a conductor are prefixed by "category.")
+	Value float64 // Field value (NaN for non numeric fields)
+	Msg   string  // Failed condition
+}
+
+func (v Violation) String() string {
+	s := v.Field + " " + v.Msg
+	if math.IsNaN(v.Value) {
+		return v.Id + ": " + s
+	}
+	return fmt.Sprintf("%s: %s (%.6g)", v.Id, s, v.Value)
+}
+
+// ValidationError All violations found validating one or more objects. Matches
+// ErrInvalidConfig with errors.Is.
+type ValidationError struct {
+	Op         string      // Operation
+	Violations []Violation // Violations found
+}
+
+func (e *ValidationError) Error() string {
+	list := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		list[i] = v.String()
+	}
+	return fmt.Sprintf("%s: %d violations: %s", e.Op, len(e.Violations), strings.Join(list, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+//----------------------------------------------------------------------------------------
+
+// validator Collects violations of one object
+type validator struct {
+	index int
+	id    string
+	list  []Violation
+}
+
+// check Adds a violation of field if ok is false
+func (v *validator) check(ok bool, field string, value float64, msg string) {
+	if !ok {
+		v.list = append(v.list, Violation{v.index, v.id, field, value, msg})
+	}
+}
+
+// err Returns a *ValidationError with violations found or nil
+func (v *validator) err(op string) error {
+	if len(v.list) == 0 {
+		return nil
+	}
+	return &ValidationError{op, v.list}
+}
+
+// objectId Returns id or name if id is empty
+func objectId(id string, name string) string {
+	if id != "" {
+		return id
+	}
+	return name
+}
+
+//----------------------------------------------------------------------------------------
+
+// Limits Returns the validation limits for cat
+func (cat *Category) Limits() *ValidationLimits {
+	if lim, ok := VALIDATION_LIMITS[cat.id]; ok {
+		return lim
+	}
+	return VALIDATION_LIMITS_DEFAULT
+}
+
+// Validate Checks all fields of cat. Returns a *ValidationError with every violation found
+// or nil.
+func (cat *Category) Validate() error {
+	v := validator{id: objectId(cat.id, cat.name)}
+	cat.validate(&v, "")
+	return v.err("Category.Validate")
+}
+
+// validate Adds violations of cat to v. Field names are prefixed by prefix.
+func (cat *Category) validate(v *validator, prefix string) {
+	lim := cat.Limits()
+	v.check(cat.name != "", prefix+"name", math.NaN(), "is empty")
+	v.check(cat.modelas > 0, prefix+"modelas", cat.modelas, "<= 0")
+	if cat.modelas > 0 {
+		v.check(cat.modelas >= lim.ModelasMin, prefix+"modelas", cat.modelas,
+			fmt.Sprintf("< %g kg/mm2", lim.ModelasMin))
+		v.check(cat.modelas <= lim.ModelasMax, prefix+"modelas", cat.modelas,
+			fmt.Sprintf("> %g kg/mm2", lim.ModelasMax))
+	}
+	v.check(cat.coefexp > 0, prefix+"coefexp", cat.coefexp, "<= 0")
+	if cat.coefexp > 0 {
+		v.check(cat.coefexp >= lim.CoefexpMin, prefix+"coefexp", cat.coefexp,
+			fmt.Sprintf("< %g 1/°C", lim.CoefexpMin))
+		v.check(cat.coefexp <= lim.CoefexpMax, prefix+"coefexp", cat.coefexp,
+			fmt.Sprintf("> %g 1/°C", lim.CoefexpMax))
+	}
+	v.check(cat.creep >= 0, prefix+"creep", cat.creep, "< 0")
+	v.check(cat.alpha > 0, prefix+"alpha", cat.alpha, "<= 0")
+	v.check(cat.alpha < 1, prefix+"alpha", cat.alpha, ">= 1")
+}
+
+// Validate Checks all fields of c and its category, the apparent density weight/area and
+// the fill factor area/(pi*diameter²/4). Returns a *ValidationError with every violation
+// found or nil.
+func (c *Conductor) Validate() error {
+	v := validator{id: objectId(c.id, c.name)}
+	c.validate(&v)
+	return v.err("Conductor.Validate")
+}
+
+// validate Adds violations of c to v
+func (c *Conductor) validate(v *validator) {
+	v.check(c.name != "", "name", math.NaN(), "is empty")
+	v.check(c.category != nil, "category", math.NaN(), "== nil")
+	if c.category != nil {
+		c.category.validate(v, "category.")
+	}
+	v.check(c.diameter > 0, "diameter", c.diameter, "<= 0")
+	v.check(c.area > 0, "area", c.area, "<= 0")
+	v.check(c.weight > 0, "weight", c.weight, "<= 0")
+	v.check(c.strength > 0, "strength", c.strength, "<= 0")
+	v.check(c.r25 > 0, "r25", c.r25, "<= 0")
+	v.check(c.hcap >= 0, "hcap", c.hcap, "< 0")
+
+	if c.area > 0 && c.weight > 0 && c.category != nil {
+		lim := c.category.Limits()
+		density := c.Density()
+		v.check(density >= lim.DensityMin, "density", density,
+			fmt.Sprintf("< %g kg/dm3", lim.DensityMin))
+		v.check(density <= lim.DensityMax, "density", density,
+			fmt.Sprintf("> %g kg/dm3", lim.DensityMax))
+	}
+	if c.area > 0 && c.diameter > 0 {
+		fill := c.FillFactor()
+		v.check(fill >= FILL_FACTOR_MIN, "fill factor", fill,
+			fmt.Sprintf("< %g (diameter and area inconsistent)", FILL_FACTOR_MIN))
+		v.check(fill <= FILL_FACTOR_MAX, "fill factor", fill,
+			fmt.Sprintf("> %g (diameter and area inconsistent)", FILL_FACTOR_MAX))
+	}
+}
+
+// Density Returns the apparent density weight/area [kg/dm3]
+func (c *Conductor) Density() float64 {
+	return 1000 * c.weight / c.area
+}
+
+// FillFactor Returns the ratio between area and the area of a circle with conductor
+// diameter
+func (c *Conductor) FillFactor() float64 {
+	return c.area / (math.Pi * c.diameter * c.diameter / 4)
+}
+
+//----------------------------------------------------------------------------------------
+
+// ValidateCategories Checks every category of list. Returns a *ValidationError with the
+// violations of all categories (Violation.Index is the position in list) or nil.
+func ValidateCategories(list []*Category) error {
+	var all []Violation
+	for i, cat := range list {
+		v := validator{index: i}
+		if cat == nil {
+			v.check(false, "category", math.NaN(), "== nil")
+		} else {
+			v.id = objectId(cat.id, cat.name)
+			cat.validate(&v, "")
+		}
+		all = append(all, v.list...)
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return &ValidationError{"ValidateCategories", all}
+}
+
+// ValidateConductors Checks every conductor of list. Returns a *ValidationError with the
+// violations of all conductors (Violation.Index is the position in list) or nil.
+func ValidateConductors(list []*Conductor) error {
+	var all []Violation
+	for i, c := range list {
+		v := validator{index: i}
+		if c == nil {
+			v.check(false, "conductor", math.NaN(), "== nil")
+		} else {
+			v.id = objectId(c.id, c.name)
+			c.validate(&v)
+		}
+		all = append(all, v.list...)
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return &ValidationError{"ValidateConductors", all}
+}
+
+//----------------------------------------------------------------------------------------
+
+// NewCategoryChecked Returns *Category object from arguments values like NewCategory.
+// Returns a *ValidationError if any value does not pass Category.Validate.
+func NewCategoryChecked(name string, modelas float64, coefexp float64, creep float64,
+	alpha float64, id string) (*Category, error) {
+	cat := NewCategory(name, modelas, coefexp, creep, alpha, id)
+	if err := cat.Validate(); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+// NewConductorChecked Returns *Conductor object from arguments values like NewConductor.
+// Returns a *ValidationError if any value does not pass Conductor.Validate.
+func NewConductorChecked(name string, category *Category, diameter float64, area float64,
+	weight float64, strength float64, r25 float64, hcap float64, id string) (*Conductor, error) {
+	c := NewConductor(name, category, diameter, area, weight, strength, r25, hcap, id)
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetChecked Returns *Category object from attributes values. Returns a *ValidationError
+// if any value does not pass Category.Validate.
+func (ca *CategoryMaker) GetChecked() (*Category, error) {
+	cat := ca.Get()
+	if err := cat.Validate(); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+// GetChecked Returns *Conductor object from attributes values. Returns a
+// *ValidationError if any value does not pass Conductor.Validate.
+func (ca *ConductorMaker) GetChecked() (*Conductor, error) {
+	c := ca.Get()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,141 @@
+// Copyright Cristian Echeverría Rabí
+
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func getValidConductor() *Conductor {
+	return NewConductor("AAAC 740,8 MCM FLINT", CC_AAAC, 25.17, 375.4, 1.035, 11250, 0.089360,
+		1e-10, "FLINT")
+}
+
+func Test_Category_Validate(t *testing.T) {
+	for _, cat := range []*Category{CC_CU, CC_AAAC, CC_ACAR, CC_ACSR, CC_AAC, CC_CUWELD} {
+		if err := cat.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := ValidateCategories([]*Category{CC_CU, CC_ACSR}); err != nil {
+		t.Error(err)
+	}
+
+	cat := NewCategory("", 12000, 0.0000230, -1, 1.5, "CU")
+	err := cat.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("*ValidationError expected got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Error("ErrInvalidConfig expected")
+	}
+	fields := []string{"name", "coefexp", "creep", "alpha"}
+	if len(verr.Violations) != len(fields) {
+		t.Fatalf("%d violations expected got %v", len(fields), err)
+	}
+	for i, v := range verr.Violations {
+		if v.Field != fields[i] || v.Id != "CU" {
+			t.Errorf("Violation of %s expected got %v", fields[i], v)
+		}
+	}
+
+	catmk := CategoryMaker{"X", 12000, 0.0000169, 0, 0.00374, ""}
+	if _, err := catmk.GetChecked(); err != nil {
+		t.Error(err)
+	}
+	catmk.Modelas = 0
+	if _, err := catmk.GetChecked(); err == nil {
+		t.Error("Modelas<=0 error expected")
+	}
+	if _, err := NewCategoryChecked("X", 100, 0.0000169, 0, 0.00374, ""); err == nil {
+		t.Error("Modelas<ModelasMin error expected")
+	}
+}
+
+func Test_Conductor_Validate(t *testing.T) {
+	c := getValidConductor()
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+	if math.Abs(c.Density()-2.757) > 0.001 {
+		t.Errorf("Density 2.757 expected got %f", c.Density())
+	}
+	if math.Abs(c.FillFactor()-0.7545) > 0.0001 {
+		t.Errorf("FillFactor 0.7545 expected got %f", c.FillFactor())
+	}
+
+	// Copper weight with aluminum category and area of a solid wire
+	cmk := ConductorMaker{"X", CC_AAAC, 20, 400, 3.6, 0, 0.1, 1e-10, "X"}
+	_, err := cmk.GetChecked()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("*ValidationError expected got %v", err)
+	}
+	fields := []string{"strength", "density", "fill factor"}
+	if len(verr.Violations) != len(fields) {
+		t.Fatalf("%d violations expected got %v", len(fields), err)
+	}
+	for i, v := range verr.Violations {
+		if v.Field != fields[i] {
+			t.Errorf("Violation of %s expected got %v", fields[i], v)
+		}
+	}
+
+	_, err = NewConductorChecked("X", nil, 0, 0, 0, 0, 0, -1, "")
+	if !errors.As(err, &verr) || len(verr.Violations) != 7 {
+		t.Errorf("7 violations expected got %v", err)
+	}
+
+	_, err = NewConductorChecked("X", NewCategory("Y", 0, 0.0000230, 20, 0.0034, ""), 25.17,
+		375.4, 1.035, 11250, 0.089360, 1e-10, "")
+	if !errors.As(err, &verr) || verr.Violations[0].Field != "category.modelas" {
+		t.Errorf("category.modelas violation expected got %v", err)
+	}
+}
+
+func Test_ValidateConductors(t *testing.T) {
+	list := []*Conductor{getValidConductor(), nil, getValidConductor(),
+		NewConductor("X", CC_AAAC, 25.17, 375.4, -1, 11250, 0.089360, 1e-10, "")}
+	err := ValidateConductors(list)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("*ValidationError expected got %v", err)
+	}
+	if len(verr.Violations) != 2 {
+		t.Fatalf("2 violations expected got %v", err)
+	}
+	if verr.Violations[0].Index != 1 || verr.Violations[1].Index != 3 {
+		t.Errorf("Index 1 and 3 expected got %v", err)
+	}
+	if verr.Violations[1].Id != "X" || verr.Violations[1].Field != "weight" {
+		t.Errorf("Violation of X weight expected got %v", verr.Violations[1])
+	}
+	if err := ValidateConductors(list[:1]); err != nil {
+		t.Error(err)
+	}
+}
+
+//----------------------------------------------------------------------------------------
+
+func Example_ValidateConductors() {
+	list := []*Conductor{
+		NewConductor("FLINT", CC_AAAC, 25.17, 375.4, 1.035, 11250, 0.08936, 0, "FLINT"),
+		NewConductor("DRAKE", CC_ACSR, 28.14, 468.5, 1.628, 14175, 0.07284, 0, "DRAKE"),
+		NewConductor("BAD", CC_AAC, 25.17, 0, 1.035, 11250, 0.08936, 0, "BAD"),
+		NewConductor("SWAP", CC_CU, 14.3, 120, 0.335, 4500, 0.1530, 0, "SWAP"),
+	}
+	err := ValidateConductors(list)
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		for _, v := range verr.Violations {
+			fmt.Printf("[%d] %v\n", v.Index, v)
+		}
+	}
+	// Output:
+	// [2] BAD: area <= 0 (0)
+	// [3] SWAP: density < 8.3 kg/dm3 (2.79167)
+}